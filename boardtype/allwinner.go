@@ -0,0 +1,30 @@
+package boardtype
+
+var (
+	OrangePiZero      = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "Zero", RAM: 0, BaseModel: &OrangePi}
+	OrangePiZero256MB = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "Zero", RAM: 256, BaseModel: &OrangePiZero}
+	OrangePiZero512MB = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "Zero", RAM: 512, BaseModel: &OrangePiZero}
+	OrangePiZero2     = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "Zero2", RAM: 0, BaseModel: &OrangePi}
+	OrangePiZero3     = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "Zero3", RAM: 0, BaseModel: &OrangePi}
+	OrangePiPC        = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "PC", RAM: 1024, BaseModel: &OrangePi}
+	BananaPi          = BoardType{Manufacturer: "Banana Pi", Model: "", SubModel: "", RAM: 0}
+	BananaPiM2Zero    = BoardType{Manufacturer: "Banana Pi", Model: "BPI", SubModel: "M2 Zero", RAM: 512, BaseModel: &BananaPi}
+	BananaPiM2Plus    = BoardType{Manufacturer: "Banana Pi", Model: "BPI", SubModel: "M2+", RAM: 1024, BaseModel: &BananaPi}
+	BananaPiM2Ultra   = BoardType{Manufacturer: "Banana Pi", Model: "BPI", SubModel: "M2 Ultra", RAM: 2048, BaseModel: &BananaPi}
+	BananaPiM64       = BoardType{Manufacturer: "Banana Pi", Model: "BPI", SubModel: "M64", RAM: 2048, BaseModel: &BananaPi}
+	Pine64            = BoardType{Manufacturer: "Pine64", Model: "", SubModel: "", RAM: 0}
+	PineA64           = BoardType{Manufacturer: "Pine64", Model: "PINE A64", SubModel: "", RAM: 512, BaseModel: &Pine64}
+	PineA64Plus       = BoardType{Manufacturer: "Pine64", Model: "PINE A64", SubModel: "+", RAM: 0, BaseModel: &Pine64}
+	PineA64Plus1GB    = BoardType{Manufacturer: "Pine64", Model: "PINE A64", SubModel: "+", RAM: 1024, BaseModel: &PineA64Plus}
+	PineA64Plus2GB    = BoardType{Manufacturer: "Pine64", Model: "PINE A64", SubModel: "+", RAM: 2048, BaseModel: &PineA64Plus}
+	PineA64LTS        = BoardType{Manufacturer: "Pine64", Model: "PINE A64", SubModel: "LTS", RAM: 2048, BaseModel: &Pine64}
+	PineH64           = BoardType{Manufacturer: "Pine64", Model: "PINE H64", SubModel: "", RAM: 0, BaseModel: &Pine64}
+	FriendlyElec      = BoardType{Manufacturer: "FriendlyElec", Model: "", SubModel: "", RAM: 0}
+	NanoPi            = BoardType{Manufacturer: "FriendlyElec", Model: "NanoPi", SubModel: "", RAM: 0, BaseModel: &FriendlyElec}
+	NanoPiNEO         = BoardType{Manufacturer: "FriendlyElec", Model: "NanoPi", SubModel: "NEO", RAM: 0, BaseModel: &NanoPi}
+	NanoPiNEO256MB    = BoardType{Manufacturer: "FriendlyElec", Model: "NanoPi", SubModel: "NEO", RAM: 256, BaseModel: &NanoPiNEO}
+	NanoPiNEO512MB    = BoardType{Manufacturer: "FriendlyElec", Model: "NanoPi", SubModel: "NEO", RAM: 512, BaseModel: &NanoPiNEO}
+	NanoPiNEOAir      = BoardType{Manufacturer: "FriendlyElec", Model: "NanoPi", SubModel: "NEO Air", RAM: 512, BaseModel: &NanoPi}
+	NanoPiNEO2        = BoardType{Manufacturer: "FriendlyElec", Model: "NanoPi", SubModel: "NEO2", RAM: 0, BaseModel: &NanoPi}
+	NanoPiDuo2        = BoardType{Manufacturer: "FriendlyElec", Model: "NanoPi", SubModel: "Duo2", RAM: 512, BaseModel: &NanoPi}
+)
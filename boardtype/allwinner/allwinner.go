@@ -0,0 +1,112 @@
+package allwinner
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+func init() {
+	identifier.RegisterBoardIdentifier(NewAllwinnerIdentifier)
+}
+
+var (
+	ErrCannotIdentifyBoard = errors.New("cannot identify Allwinner board")
+)
+
+type allwinnerBoard struct {
+	Compatible string
+	Model      string
+	Type       boardtype.SBC
+	Variants   []boardtype.SBC
+}
+
+var allwinnerBoards = []allwinnerBoard{
+	{"xunlong,orangepi-zero", "Orange Pi Zero", boardtype.OrangePiZero, []boardtype.SBC{boardtype.OrangePiZero256MB, boardtype.OrangePiZero512MB}},
+	{"xunlong,orangepi-zero2", "Orange Pi Zero2", boardtype.OrangePiZero2, nil},
+	{"xunlong,orangepi-zero3", "Orange Pi Zero3", boardtype.OrangePiZero3, nil},
+	{"xunlong,orangepi-pc", "Orange Pi PC", boardtype.OrangePiPC, nil},
+	{"sinovoip,bpi-m2-zero", "Banana Pi BPI-M2-Zero", boardtype.BananaPiM2Zero, nil},
+	{"sinovoip,bpi-m2-plus", "Banana Pi BPI-M2-Plus", boardtype.BananaPiM2Plus, nil},
+	{"sinovoip,bpi-m2-ultra", "Banana Pi BPI-M2-Ultra", boardtype.BananaPiM2Ultra, nil},
+	{"sinovoip,bananapi-m64", "BananaPi-M64", boardtype.BananaPiM64, nil},
+	{"pine64,pine64-lts", "Pine64 LTS", boardtype.PineA64LTS, nil},
+	{"pine64,pine64-plus", "Pine64+", boardtype.PineA64Plus, []boardtype.SBC{boardtype.PineA64Plus1GB, boardtype.PineA64Plus2GB}},
+	{"pine64,pine64", "Pine64", boardtype.PineA64, nil},
+	{"pine64,pine-h64", "Pine H64", boardtype.PineH64, nil},
+	{"friendlyarm,nanopi-neo-air", "NanoPi NEO Air", boardtype.NanoPiNEOAir, nil},
+	{"friendlyarm,nanopi-neo2", "NanoPi NEO 2", boardtype.NanoPiNEO2, nil},
+	{"friendlyarm,nanopi-neo", "NanoPi NEO", boardtype.NanoPiNEO, []boardtype.SBC{boardtype.NanoPiNEO256MB, boardtype.NanoPiNEO512MB}},
+	{"friendlyarm,nanopi-duo2", "NanoPi Duo2", boardtype.NanoPiDuo2, nil},
+}
+
+// The device tree models of some boards start with the maker's name, the table doesn't.
+var allwinnerModelPrefixes = []string{"Xunlong ", "FriendlyARM ", "FriendlyElec "}
+
+type allwinnerIdentifier struct {
+	logger *slog.Logger
+}
+
+func NewAllwinnerIdentifier(logger *slog.Logger) identifier.BoardIdentifier {
+	logger.Debug("initializing Allwinner identifier")
+	newLogger := logger.With(slog.String("source", "Allwinner"))
+	return allwinnerIdentifier{
+		logger: newLogger,
+	}
+}
+
+func (r allwinnerIdentifier) Name() string {
+	return "Allwinner Identifier"
+}
+
+func (r allwinnerIdentifier) GetBoardType() (boardtype.SBC, error) {
+	compatible, err := identifier.GetDeviceTreeCompatible(r.logger)
+	if err != nil {
+		return nil, ErrCannotIdentifyBoard
+	}
+	model, _ := identifier.GetDeviceTreeModel(r.logger)
+	board, ok := getAllwinnerBoard(compatible, model)
+	if !ok {
+		r.logger.Debug("unknown board", slog.String("model", model))
+		return nil, ErrCannotIdentifyBoard
+	}
+	variant, _ := identifier.ResolveRAMVariant(r.logger, board.Type, board.Variants)
+	return variant, nil
+}
+
+// The model is only tried when no board compatible matches. The vendor prefixes (Xunlong,
+// FriendlyARM) are stripped and the rest compared exactly, as Orange Pi Zero is a prefix of
+// Orange Pi Zero2 and NanoPi NEO of NanoPi NEO Air.
+func getAllwinnerBoard(compatible []string, model string) (allwinnerBoard, bool) {
+	for _, c := range compatible {
+		for _, b := range allwinnerBoards {
+			if c == b.Compatible {
+				return b, true
+			}
+		}
+	}
+	if !isAllwinner(compatible) {
+		return allwinnerBoard{}, false
+	}
+	for _, prefix := range allwinnerModelPrefixes {
+		model = strings.TrimPrefix(model, prefix)
+	}
+	for _, b := range allwinnerBoards {
+		if strings.EqualFold(model, b.Model) {
+			return b, true
+		}
+	}
+	return allwinnerBoard{}, false
+}
+
+func isAllwinner(compatible []string) bool {
+	for _, c := range compatible {
+		if strings.HasPrefix(c, "allwinner,") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package allwinner
+
+import (
+	"encoding/binary"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+)
+
+func TestGetAllwinnerBoard(t *testing.T) {
+	tests := []struct {
+		compatible []string
+		model      string
+		expected   boardtype.SBC
+		vendor     boardtype.SBC
+	}{
+		{[]string{"xunlong,orangepi-zero", "allwinner,sun8i-h2-plus"}, "Xunlong Orange Pi Zero", boardtype.OrangePiZero, boardtype.OrangePi},
+		{[]string{"sinovoip,bpi-m2-zero", "allwinner,sun8i-h2-plus"}, "Banana Pi BPI-M2-Zero", boardtype.BananaPiM2Zero, boardtype.BananaPi},
+		{[]string{"pine64,pine64-plus", "allwinner,sun50i-a64"}, "Pine64+", boardtype.PineA64Plus, boardtype.Pine64},
+		{[]string{"friendlyarm,nanopi-neo", "allwinner,sun8i-h3"}, "FriendlyARM NanoPi NEO", boardtype.NanoPiNEO, boardtype.FriendlyElec},
+		{[]string{"orangepi,zero", "allwinner,sun8i-h2-plus"}, "Orange Pi Zero", boardtype.OrangePiZero, boardtype.OrangePi},
+		{[]string{"friendlyarm,nanopi-neo-core", "allwinner,sun8i-h3"}, "FriendlyARM NanoPi NEO", boardtype.NanoPiNEO, boardtype.FriendlyElec},
+	}
+	for _, test := range tests {
+		t.Run(test.model, func(t *testing.T) {
+			board, ok := getAllwinnerBoard(test.compatible, test.model)
+			require.True(t, ok)
+			assert.Equal(t, test.expected, board.Type)
+			assert.True(t, board.Type.IsBoardType(test.vendor))
+		})
+	}
+	_, ok := getAllwinnerBoard([]string{"xunlong,orangepi-5", "rockchip,rk3588s"}, "Orange Pi Zero")
+	assert.False(t, ok)
+}
+
+func TestReadSID(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	data := make([]byte, 256)
+	binary.LittleEndian.PutUint32(data[0:], 0x02c00042)
+	binary.LittleEndian.PutUint32(data[4:], 0x33806c04)
+	binary.LittleEndian.PutUint32(data[8:], 0x7c42c3d1)
+	binary.LittleEndian.PutUint32(data[12:], 0x0c0b1f2a)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nvmem")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	sid, err := readSID(logger, []string{filepath.Join(dir, "missing"), path}, []string{"xunlong,orangepi-zero", "allwinner,sun8i-h3"})
+	require.NoError(t, err)
+	assert.Equal(t, "02c0004233806c047c42c3d10c0b1f2a", sid.Serial)
+	assert.Equal(t, uint16(0x0042), sid.ChipID)
+	assert.Equal(t, "H2+", sid.SoC)
+
+	binary.LittleEndian.PutUint32(data[0:], 0x12345678)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	sid, err = readSID(logger, []string{path}, []string{"pine64,pine64-plus", "allwinner,sun50i-a64"})
+	require.NoError(t, err)
+	assert.Equal(t, "A64", sid.SoC)
+
+	_, err = ParseSID(make([]byte, 256))
+	assert.Equal(t, ErrInvalidSID, err)
+	_, err = readSID(logger, []string{filepath.Join(dir, "missing")}, nil)
+	assert.Equal(t, ErrSIDNotFound, err)
+}
@@ -0,0 +1,104 @@
+package allwinner
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+const (
+	sidSize = 16
+)
+
+var (
+	ErrSIDNotFound = errors.New("SID not found")
+	ErrInvalidSID  = errors.New("invalid SID")
+	sidPaths       = []string{"/sys/bus/nvmem/devices/sunxi-sid0/nvmem"}
+	sidChipIDs     = map[uint16]string{0x0042: "H2+", 0x0081: "H3"}
+)
+
+// The H2+ and H3 share a compatible string, only the chip ID in the SID tells them apart.
+var allwinnerSoCsByCompatible = []struct {
+	Compatible string
+	SoC        string
+}{
+	{"allwinner,sun8i-h2-plus", "H2+"},
+	{"allwinner,sun8i-h3", "H3"},
+	{"allwinner,sun50i-h5", "H5"},
+	{"allwinner,sun50i-h6", "H6"},
+	{"allwinner,sun50i-h616", "H616"},
+	{"allwinner,sun50i-h618", "H618"},
+	{"allwinner,sun50i-a64", "A64"},
+	{"allwinner,sun8i-r40", "R40"},
+}
+
+// SID is the security ID, the first 16 bytes are unique per chip and the low half of the first
+// word identifies the die on the H2+ and H3.
+type SID struct {
+	Serial string
+	ChipID uint16
+	SoC    string
+}
+
+func GetSID(logger *slog.Logger) (*SID, error) {
+	compatible, _ := identifier.GetDeviceTreeCompatible(logger)
+	return readSID(logger, sidPaths, compatible)
+}
+
+func readSID(logger *slog.Logger, paths []string, compatible []string) (*SID, error) {
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			logger.Debug("cannot read SID", slog.String("path", path), slog.Any("error", err))
+			continue
+		}
+		sid, err := ParseSID(data)
+		if err != nil {
+			logger.Debug("cannot parse SID", slog.String("path", path), slog.Any("error", err))
+			continue
+		}
+		if sid.SoC == "" {
+			sid.SoC = getSoCFromCompatible(compatible)
+		}
+		logger.Debug("SID", slog.String("serial", sid.Serial), slog.String("soc", sid.SoC))
+		return sid, nil
+	}
+	return nil, ErrSIDNotFound
+}
+
+// ParseSID reads the SID as the kernel exposes it, four little endian words.
+func ParseSID(data []byte) (*SID, error) {
+	if len(data) < sidSize {
+		return nil, ErrInvalidSID
+	}
+	words := make([]string, 4)
+	blank := true
+	for i := range words {
+		w := binary.LittleEndian.Uint32(data[i*4 : i*4+4])
+		if w != 0 {
+			blank = false
+		}
+		words[i] = fmt.Sprintf("%08x", w)
+	}
+	if blank {
+		return nil, ErrInvalidSID
+	}
+	chipID := uint16(binary.LittleEndian.Uint32(data[0:4]) & 0xffff)
+	return &SID{Serial: strings.Join(words, ""), ChipID: chipID, SoC: sidChipIDs[chipID]}, nil
+}
+
+func getSoCFromCompatible(compatible []string) string {
+	for _, s := range allwinnerSoCsByCompatible {
+		for _, c := range compatible {
+			if c == s.Compatible {
+				return s.SoC
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,22 @@
+package boardtype
+
+var (
+	Khadas                      = BoardType{Manufacturer: "Khadas", Model: "", SubModel: "", RAM: 0}
+	KhadasVIM                   = BoardType{Manufacturer: "Khadas", Model: "VIM", SubModel: "", RAM: 0, BaseModel: &Khadas}
+	KhadasVIM1                  = BoardType{Manufacturer: "Khadas", Model: "VIM", SubModel: "1", RAM: 2048, BaseModel: &KhadasVIM}
+	KhadasVIM2                  = BoardType{Manufacturer: "Khadas", Model: "VIM", SubModel: "2", RAM: 0, BaseModel: &KhadasVIM}
+	KhadasVIM3                  = BoardType{Manufacturer: "Khadas", Model: "VIM", SubModel: "3", RAM: 0, BaseModel: &KhadasVIM}
+	KhadasVIM32GB               = BoardType{Manufacturer: "Khadas", Model: "VIM", SubModel: "3", RAM: 2048, BaseModel: &KhadasVIM3}
+	KhadasVIM34GB               = BoardType{Manufacturer: "Khadas", Model: "VIM", SubModel: "3", RAM: 4096, BaseModel: &KhadasVIM3}
+	KhadasVIM3L                 = BoardType{Manufacturer: "Khadas", Model: "VIM", SubModel: "3L", RAM: 2048, BaseModel: &KhadasVIM}
+	KhadasVIM4                  = BoardType{Manufacturer: "Khadas", Model: "VIM", SubModel: "4", RAM: 8192, BaseModel: &KhadasVIM}
+	LibreComputer               = BoardType{Manufacturer: "Libre Computer", Model: "", SubModel: "", RAM: 0}
+	LibreComputerLePotato       = BoardType{Manufacturer: "Libre Computer", Model: "Le Potato", SubModel: "AML-S905X-CC", RAM: 0, BaseModel: &LibreComputer}
+	LibreComputerLePotato1GB    = BoardType{Manufacturer: "Libre Computer", Model: "Le Potato", SubModel: "AML-S905X-CC", RAM: 1024, BaseModel: &LibreComputerLePotato}
+	LibreComputerLePotato2GB    = BoardType{Manufacturer: "Libre Computer", Model: "Le Potato", SubModel: "AML-S905X-CC", RAM: 2048, BaseModel: &LibreComputerLePotato}
+	LibreComputerSweetPotato    = BoardType{Manufacturer: "Libre Computer", Model: "Sweet Potato", SubModel: "AML-S905X-CC-V2", RAM: 0, BaseModel: &LibreComputer}
+	LibreComputerSweetPotato1GB = BoardType{Manufacturer: "Libre Computer", Model: "Sweet Potato", SubModel: "AML-S905X-CC-V2", RAM: 1024, BaseModel: &LibreComputerSweetPotato}
+	LibreComputerSweetPotato2GB = BoardType{Manufacturer: "Libre Computer", Model: "Sweet Potato", SubModel: "AML-S905X-CC-V2", RAM: 2048, BaseModel: &LibreComputerSweetPotato}
+	LibreComputerAlta           = BoardType{Manufacturer: "Libre Computer", Model: "Alta", SubModel: "AML-A311D-CC", RAM: 0, BaseModel: &LibreComputer}
+	LibreComputerSolitude       = BoardType{Manufacturer: "Libre Computer", Model: "Solitude", SubModel: "AML-S905D3-CC", RAM: 0, BaseModel: &LibreComputer}
+)
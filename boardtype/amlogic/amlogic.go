@@ -0,0 +1,76 @@
+package amlogic
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+func init() {
+	identifier.RegisterBoardIdentifier(NewAmlogicIdentifier)
+}
+
+var (
+	ErrCannotIdentifyBoard = errors.New("cannot identify Amlogic board")
+)
+
+type amlogicBoard struct {
+	Compatible string
+	Type       boardtype.SBC
+	Variants   []boardtype.SBC
+}
+
+var amlogicBoards = []amlogicBoard{
+	{"khadas,vim", boardtype.KhadasVIM1, nil},
+	{"khadas,vim2", boardtype.KhadasVIM2, nil},
+	{"khadas,vim3", boardtype.KhadasVIM3, []boardtype.SBC{boardtype.KhadasVIM32GB, boardtype.KhadasVIM34GB}},
+	{"khadas,vim3l", boardtype.KhadasVIM3L, nil},
+	{"khadas,vim4", boardtype.KhadasVIM4, nil},
+	{"libretech,aml-s905x-cc", boardtype.LibreComputerLePotato, []boardtype.SBC{boardtype.LibreComputerLePotato1GB, boardtype.LibreComputerLePotato2GB}},
+	{"libretech,aml-s905x-cc-v2", boardtype.LibreComputerSweetPotato, []boardtype.SBC{boardtype.LibreComputerSweetPotato1GB, boardtype.LibreComputerSweetPotato2GB}},
+	{"libretech,aml-a311d-cc", boardtype.LibreComputerAlta, nil},
+	{"libretech,aml-s905d3-cc", boardtype.LibreComputerSolitude, nil},
+}
+
+type amlogicIdentifier struct {
+	logger *slog.Logger
+}
+
+func NewAmlogicIdentifier(logger *slog.Logger) identifier.BoardIdentifier {
+	logger.Debug("initializing Amlogic identifier")
+	newLogger := logger.With(slog.String("source", "Amlogic"))
+	return amlogicIdentifier{
+		logger: newLogger,
+	}
+}
+
+func (r amlogicIdentifier) Name() string {
+	return "Amlogic Identifier"
+}
+
+func (r amlogicIdentifier) GetBoardType() (boardtype.SBC, error) {
+	compatible, err := identifier.GetDeviceTreeCompatible(r.logger)
+	if err != nil {
+		return nil, ErrCannotIdentifyBoard
+	}
+	board, ok := getAmlogicBoard(compatible)
+	if !ok {
+		r.logger.Debug("unknown board", slog.Any("compatible", compatible))
+		return nil, ErrCannotIdentifyBoard
+	}
+	variant, _ := identifier.ResolveRAMVariant(r.logger, board.Type, board.Variants)
+	return variant, nil
+}
+
+func getAmlogicBoard(compatible []string) (amlogicBoard, bool) {
+	for _, c := range compatible {
+		for _, b := range amlogicBoards {
+			if c == b.Compatible {
+				return b, true
+			}
+		}
+	}
+	return amlogicBoard{}, false
+}
@@ -0,0 +1,76 @@
+package amlogic
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+func TestParseSoCID(t *testing.T) {
+	tests := []struct {
+		id      string
+		family  string
+		pkg     string
+		display string
+	}{
+		{"G12B (A311D)", "G12B", "A311D", "A311D (G12B)"},
+		{"GXL (S905X)", "GXL", "S905X", "S905X (GXL)"},
+		{"SM1 (S905D3)", "SM1", "S905D3", "S905D3 (SM1)"},
+		{"GXM (Unknown)", "GXM", "", "GXM"},
+		{"AXG", "AXG", "", "AXG"},
+	}
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			family, pkg := ParseSoCID(test.id)
+			assert.Equal(t, test.family, family)
+			assert.Equal(t, test.pkg, pkg)
+			assert.Equal(t, test.display, SoC{Family: family, Package: pkg}.String())
+		})
+	}
+}
+
+func TestParseSoC(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	soc, err := parseSoC(logger, &identifier.SoC{Family: "Amlogic Meson", ID: "G12B (A311D)", Revision: "29:b - 10:2"})
+	require.NoError(t, err)
+	assert.Equal(t, &SoC{Family: "G12B", Package: "A311D", Revision: "29:b - 10:2"}, soc)
+
+	_, err = parseSoC(logger, &identifier.SoC{Family: "Tegra", ID: "35"})
+	assert.Equal(t, ErrNotAmlogic, err)
+}
+
+func TestGetAmlogicBoard(t *testing.T) {
+	tests := []struct {
+		compatible []string
+		expected   boardtype.SBC
+		vendor     boardtype.SBC
+	}{
+		{[]string{"khadas,vim3", "amlogic,a311d", "amlogic,g12b"}, boardtype.KhadasVIM3, boardtype.Khadas},
+		{[]string{"khadas,vim3l", "amlogic,sm1"}, boardtype.KhadasVIM3L, boardtype.Khadas},
+		{[]string{"libretech,aml-s905x-cc", "amlogic,s905x", "amlogic,meson-gxl"}, boardtype.LibreComputerLePotato, boardtype.LibreComputer},
+		{[]string{"libretech,aml-s905x-cc-v2", "amlogic,s905x", "amlogic,meson-gxl"}, boardtype.LibreComputerSweetPotato, boardtype.LibreComputer},
+		{[]string{"libretech,aml-a311d-cc", "amlogic,a311d", "amlogic,g12b"}, boardtype.LibreComputerAlta, boardtype.LibreComputer},
+	}
+	for _, test := range tests {
+		t.Run(test.compatible[0], func(t *testing.T) {
+			board, ok := getAmlogicBoard(test.compatible)
+			require.True(t, ok)
+			assert.Equal(t, test.expected, board.Type)
+			assert.True(t, board.Type.IsBoardType(test.vendor))
+		})
+	}
+	_, ok := getAmlogicBoard([]string{"hardkernel,odroid-n2", "amlogic,s922x", "amlogic,g12b"})
+	assert.False(t, ok)
+}
+
+func TestLibreComputerPrettyName(t *testing.T) {
+	assert.Equal(t, "Libre Computer Alta AML-A311D-CC", boardtype.LibreComputerAlta.GetPrettyName())
+	assert.Equal(t, "Libre Computer Solitude AML-S905D3-CC", boardtype.LibreComputerSolitude.GetPrettyName())
+	assert.Equal(t, "Libre Computer Le Potato AML-S905X-CC 2GB", boardtype.LibreComputerLePotato2GB.GetPrettyName())
+}
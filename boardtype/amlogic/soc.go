@@ -0,0 +1,61 @@
+package amlogic
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+var (
+	ErrNotAmlogic = errors.New("SoC is not an Amlogic SoC")
+)
+
+// SoC is the Amlogic SoC as meson-gx-socinfo reports it, soc_id is the family followed by the
+// package in parentheses, for example G12B (A311D).
+type SoC struct {
+	Family   string
+	Package  string
+	Revision string
+}
+
+func (s SoC) String() string {
+	if s.Package == "" {
+		return s.Family
+	}
+	return s.Package + " (" + s.Family + ")"
+}
+
+func GetSoC(logger *slog.Logger) (*SoC, error) {
+	soc, err := identifier.GetSoC(logger)
+	if err != nil {
+		return nil, err
+	}
+	return parseSoC(logger, soc)
+}
+
+func parseSoC(logger *slog.Logger, soc *identifier.SoC) (*SoC, error) {
+	if !strings.HasPrefix(soc.Family, "Amlogic") {
+		logger.Debug("SoC is not an Amlogic SoC", slog.String("family", soc.Family))
+		return nil, ErrNotAmlogic
+	}
+	family, pkg := ParseSoCID(soc.ID)
+	ret := &SoC{Family: family, Package: pkg, Revision: soc.Revision}
+	logger.Debug("Amlogic SoC", slog.String("family", ret.Family), slog.String("package", ret.Package), slog.String("revision", ret.Revision))
+	return ret, nil
+}
+
+// ParseSoCID splits G12B (A311D) into the family and the package, the package is empty when
+// the kernel doesn't know it.
+func ParseSoCID(id string) (string, string) {
+	family, pkg, ok := strings.Cut(strings.TrimSpace(id), "(")
+	if !ok {
+		return strings.TrimSpace(id), ""
+	}
+	pkg = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(pkg), ")"))
+	if strings.EqualFold(pkg, "Unknown") {
+		pkg = ""
+	}
+	return strings.TrimSpace(family), pkg
+}
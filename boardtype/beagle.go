@@ -0,0 +1,16 @@
+package boardtype
+
+var (
+	BeagleBoard             = BoardType{Manufacturer: "BeagleBoard.org", Model: "", SubModel: "", RAM: 0}
+	BeagleBone              = BoardType{Manufacturer: "BeagleBoard.org", Model: "BeagleBone", SubModel: "", RAM: 0, BaseModel: &BeagleBoard}
+	BeagleBoneBlack         = BoardType{Manufacturer: "BeagleBoard.org", Model: "BeagleBone", SubModel: "Black", RAM: 512, BaseModel: &BeagleBone}
+	BeagleBoneBlackWireless = BoardType{Manufacturer: "BeagleBoard.org", Model: "BeagleBone", SubModel: "Black Wireless", RAM: 512, BaseModel: &BeagleBoneBlack}
+	BeagleBoneGreen         = BoardType{Manufacturer: "BeagleBoard.org", Model: "BeagleBone", SubModel: "Green", RAM: 512, BaseModel: &BeagleBone}
+	BeagleBoneGreenWireless = BoardType{Manufacturer: "BeagleBoard.org", Model: "BeagleBone", SubModel: "Green Wireless", RAM: 512, BaseModel: &BeagleBoneGreen}
+	BeagleBoneBlue          = BoardType{Manufacturer: "BeagleBoard.org", Model: "BeagleBone", SubModel: "Blue", RAM: 512, BaseModel: &BeagleBone}
+	BeagleBoneAI            = BoardType{Manufacturer: "BeagleBoard.org", Model: "BeagleBone", SubModel: "AI", RAM: 1024, BaseModel: &BeagleBone}
+	BeagleBoneAI64          = BoardType{Manufacturer: "BeagleBoard.org", Model: "BeagleBone", SubModel: "AI-64", RAM: 4096, BaseModel: &BeagleBone}
+	PocketBeagle            = BoardType{Manufacturer: "BeagleBoard.org", Model: "PocketBeagle", SubModel: "", RAM: 512, BaseModel: &BeagleBoard}
+	BeaglePlay              = BoardType{Manufacturer: "BeagleBoard.org", Model: "BeaglePlay", SubModel: "", RAM: 2048, BaseModel: &BeagleBoard}
+	BeagleYAI               = BoardType{Manufacturer: "BeagleBoard.org", Model: "BeagleY-AI", SubModel: "", RAM: 4096, BaseModel: &BeagleBoard}
+)
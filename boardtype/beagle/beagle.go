@@ -0,0 +1,166 @@
+package beagle
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+func init() {
+	identifier.RegisterBoardIdentifier(NewBeagleIdentifier)
+}
+
+var (
+	ErrCannotIdentifyBoard = errors.New("cannot identify BeagleBoard.org board")
+)
+
+type beagleEEPROM struct {
+	BoardName string
+	Version   string
+	Type      boardtype.SBC
+}
+
+// Boards sharing a board name are told apart by the version prefix, the first match wins so
+// the more specific entries come first.
+var beagleBoardsByEEPROM = []beagleEEPROM{
+	{"A335BNLT", "BBG", boardtype.BeagleBoneGreen},
+	{"A335BNLT", "GW", boardtype.BeagleBoneGreenWireless},
+	{"A335BNLT", "BW", boardtype.BeagleBoneBlackWireless},
+	{"A335BNLT", "BLA", boardtype.BeagleBoneBlue},
+	{"A335BNLT", "", boardtype.BeagleBoneBlack},
+	{"A335PBGL", "", boardtype.PocketBeagle},
+	{"A335BONE", "", boardtype.BeagleBone},
+	{"BBONE-AI", "", boardtype.BeagleBoneAI},
+	{"BBONEAI-64", "", boardtype.BeagleBoneAI64},
+	{"BEAGLEPLAY", "", boardtype.BeaglePlay},
+	{"BEAGLEY-AI", "", boardtype.BeagleYAI},
+}
+
+type beagle struct {
+	Model string
+	Type  boardtype.SBC
+}
+
+// The compatible list goes from most to least specific, a BeagleBone Green Wireless also lists
+// ti,am335x-bone-green and ti,am335x-bone-black.
+var beagleBoardsByCompatible = []beagle{
+	{"ti,am335x-bone-black-wireless", boardtype.BeagleBoneBlackWireless},
+	{"ti,am335x-bone-black", boardtype.BeagleBoneBlack},
+	{"ti,am335x-bone-green-wireless", boardtype.BeagleBoneGreenWireless},
+	{"ti,am335x-bone-green", boardtype.BeagleBoneGreen},
+	{"ti,am335x-bone-blue", boardtype.BeagleBoneBlue},
+	{"ti,am335x-pocketbeagle", boardtype.PocketBeagle},
+	{"ti,am335x-bone", boardtype.BeagleBone},
+	{"beagle,am5729-beagleboneai", boardtype.BeagleBoneAI},
+	{"beagle,j721e-beagleboneai64", boardtype.BeagleBoneAI64},
+	{"beagle,am625-beagleplay", boardtype.BeaglePlay},
+	{"beagle,am67a-beagley-ai", boardtype.BeagleYAI},
+}
+
+var beagleBoardsByDeviceTreeModel = []beagle{
+	{"BeagleBone Black Wireless", boardtype.BeagleBoneBlackWireless},
+	{"BeagleBone Black", boardtype.BeagleBoneBlack},
+	{"BeagleBone Green Wireless", boardtype.BeagleBoneGreenWireless},
+	{"BeagleBone Green", boardtype.BeagleBoneGreen},
+	{"BeagleBone Blue", boardtype.BeagleBoneBlue},
+	{"PocketBeagle", boardtype.PocketBeagle},
+	{"BeagleBone AI-64", boardtype.BeagleBoneAI64},
+	{"BeagleBone AI", boardtype.BeagleBoneAI},
+	{"BeaglePlay", boardtype.BeaglePlay},
+	{"BeagleY-AI", boardtype.BeagleYAI},
+	{"TI AM335x BeagleBone", boardtype.BeagleBone},
+}
+
+type beagleIdentifier struct {
+	logger *slog.Logger
+}
+
+func NewBeagleIdentifier(logger *slog.Logger) identifier.BoardIdentifier {
+	logger.Debug("initializing BeagleBoard identifier")
+	newLogger := logger.With(slog.String("source", "BeagleBoard"))
+	return beagleIdentifier{
+		logger: newLogger,
+	}
+}
+
+func (r beagleIdentifier) Name() string {
+	return "BeagleBoard Identifier"
+}
+
+type beagleSource struct {
+	Name         string
+	GetBoardType func(*slog.Logger) (boardtype.SBC, error)
+}
+
+var beagleSources = []beagleSource{
+	{"board EEPROM", getBoardTypeFromEEPROM},
+	{"device tree compatible", getBoardTypeFromCompatible},
+	{"device tree model", getBoardTypeFromDeviceTreeModel},
+}
+
+func (r beagleIdentifier) GetBoardType() (boardtype.SBC, error) {
+	for _, source := range beagleSources {
+		boardType, err := source.GetBoardType(r.logger)
+		if err != nil {
+			r.logger.Debug("cannot identify board", slog.String("source", source.Name), slog.Any("error", err))
+			continue
+		}
+		r.logger.Debug("board type", slog.String("source", source.Name), slog.String("type", boardType.GetPrettyName()))
+		return boardType, nil
+	}
+	return nil, ErrCannotIdentifyBoard
+}
+
+func getBoardTypeFromEEPROM(logger *slog.Logger) (boardtype.SBC, error) {
+	eeprom, err := GetEEPROM(logger)
+	if err != nil {
+		return nil, err
+	}
+	return getBoardTypeByEEPROM(logger, eeprom)
+}
+
+func getBoardTypeByEEPROM(logger *slog.Logger, eeprom *EEPROM) (boardtype.SBC, error) {
+	for _, b := range beagleBoardsByEEPROM {
+		if strings.HasPrefix(eeprom.BoardName, b.BoardName) && strings.HasPrefix(eeprom.Version, b.Version) {
+			return b.Type, nil
+		}
+	}
+	logger.Debug("EEPROM does not match any boards", slog.String("name", eeprom.BoardName), slog.String("version", eeprom.Version))
+	return nil, ErrCannotIdentifyBoard
+}
+
+func getBoardTypeFromCompatible(logger *slog.Logger) (boardtype.SBC, error) {
+	compatible, err := identifier.GetDeviceTreeCompatible(logger)
+	if err != nil {
+		return nil, err
+	}
+	return getBoardTypeByCompatible(compatible)
+}
+
+func getBoardTypeByCompatible(compatible []string) (boardtype.SBC, error) {
+	for _, c := range compatible {
+		for _, b := range beagleBoardsByCompatible {
+			if c == b.Model {
+				return b.Type, nil
+			}
+		}
+	}
+	return nil, ErrCannotIdentifyBoard
+}
+
+func getBoardTypeFromDeviceTreeModel(logger *slog.Logger) (boardtype.SBC, error) {
+	model, err := identifier.GetDeviceTreeModel(logger)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range beagleBoardsByDeviceTreeModel {
+		if strings.Contains(model, b.Model) {
+			return b.Type, nil
+		}
+	}
+	logger.Debug("device tree model does not match any boards", slog.String("model", model))
+	return nil, ErrCannotIdentifyBoard
+}
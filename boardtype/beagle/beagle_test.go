@@ -0,0 +1,126 @@
+package beagle
+
+import (
+	"encoding/binary"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+)
+
+func buildLegacyEEPROM(name, version, serial string) []byte {
+	data := make([]byte, 256)
+	for i := range data {
+		data[i] = 0xff
+	}
+	binary.LittleEndian.PutUint32(data[0:4], tiEEPROMMagic)
+	copy(data[legacyEEPROMBoardName:], name)
+	copy(data[legacyEEPROMVersion:], version)
+	copy(data[legacyEEPROMSerial:], serial)
+	return data
+}
+
+func buildK3EEPROM(name, version, boardID, serial string) []byte {
+	data := make([]byte, 0, 256)
+	data = binary.LittleEndian.AppendUint32(data, tiEEPROMMagic)
+	data = append(data, k3EEPROMRecordBoardID)
+	data = binary.LittleEndian.AppendUint16(data, 0x37)
+	data = append(data, k3EEPROMRecordBoardInfo)
+	data = binary.LittleEndian.AppendUint16(data, k3EEPROMBoardInfoSize)
+	info := make([]byte, k3EEPROMBoardInfoSize)
+	copy(info[0:16], name)
+	copy(info[16:18], version)
+	copy(info[18:22], "0001")
+	copy(info[22:24], "01")
+	copy(info[24:26], "A0")
+	copy(info[32:36], "2423")
+	copy(info[36:42], boardID)
+	copy(info[42:46], serial)
+	data = append(data, info...)
+	data = append(data, k3EEPROMRecordEndList, 0, 0)
+	for len(data) < 256 {
+		data = append(data, 0xff)
+	}
+	return data
+}
+
+func TestParseEEPROM(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	tests := []struct {
+		name     string
+		data     []byte
+		format   EEPROMFormat
+		expected boardtype.SBC
+	}{
+		{"black", buildLegacyEEPROM("A335BNLT", "00C0", "4019BBBK0A2C"), EEPROMFormatLegacy, boardtype.BeagleBoneBlack},
+		{"green", buildLegacyEEPROM("A335BNLT", "BBG1", "BBG117045678"), EEPROMFormatLegacy, boardtype.BeagleBoneGreen},
+		{"green wireless", buildLegacyEEPROM("A335BNLT", "GW1A", "GW1A17051234"), EEPROMFormatLegacy, boardtype.BeagleBoneGreenWireless},
+		{"pocketbeagle", buildLegacyEEPROM("A335PBGL", "00A2", "1234PBGL5678"), EEPROMFormatLegacy, boardtype.PocketBeagle},
+		{"beagleplay", buildK3EEPROM("BEAGLEPLAY-A0-", "02", "B00000", "0042"), EEPROMFormatK3, boardtype.BeaglePlay},
+		{"ai-64", buildK3EEPROM("BBONEAI-64-B0-", "B0", "000001", "0107"), EEPROMFormatK3, boardtype.BeagleBoneAI64},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			eeprom, err := ParseEEPROM(test.data)
+			require.NoError(t, err)
+			assert.Equal(t, test.format, eeprom.Format)
+			board, err := getBoardTypeByEEPROM(logger, eeprom)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, board)
+		})
+	}
+
+	eeprom, err := ParseEEPROM(buildK3EEPROM("BEAGLEPLAY-A0-", "02", "B00000", "0042"))
+	require.NoError(t, err)
+	assert.Equal(t, "0001", eeprom.ProcessorNumber)
+	assert.Equal(t, "A0", eeprom.PCBRevision)
+	assert.Equal(t, "2423B000000042", eeprom.Serial)
+
+	_, err = ParseEEPROM(make([]byte, 256))
+	assert.Equal(t, ErrInvalidEEPROM, err)
+	_, err = ParseEEPROM([]byte{0xaa, 0x55, 0x33, 0xee})
+	assert.Equal(t, ErrInvalidEEPROM, err)
+}
+
+func TestReadEEPROM(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	dir := t.TempDir()
+	blank := filepath.Join(dir, "blank")
+	valid := filepath.Join(dir, "valid")
+	require.NoError(t, os.WriteFile(blank, make([]byte, 256), 0644))
+	require.NoError(t, os.WriteFile(valid, buildLegacyEEPROM("A335BNLT", "00C0", "4019BBBK0A2C"), 0644))
+
+	eeprom, err := readEEPROM(logger, []string{filepath.Join(dir, "missing"), blank, valid})
+	require.NoError(t, err)
+	assert.Equal(t, "4019BBBK0A2C", eeprom.Serial)
+
+	_, err = readEEPROM(logger, []string{blank})
+	assert.Equal(t, ErrEEPROMNotFound, err)
+}
+
+func TestGetBoardTypeByCompatible(t *testing.T) {
+	tests := []struct {
+		compatible []string
+		expected   boardtype.SBC
+	}{
+		{[]string{"ti,am335x-bone-green-wireless", "ti,am335x-bone-green", "ti,am335x-bone-black", "ti,am335x-bone", "ti,am33xx"}, boardtype.BeagleBoneGreenWireless},
+		{[]string{"ti,am335x-bone-black", "ti,am335x-bone", "ti,am33xx"}, boardtype.BeagleBoneBlack},
+		{[]string{"beagle,am625-beagleplay", "ti,am625"}, boardtype.BeaglePlay},
+		{[]string{"beagle,am67a-beagley-ai", "ti,j722s"}, boardtype.BeagleYAI},
+	}
+	for _, test := range tests {
+		t.Run(test.compatible[0], func(t *testing.T) {
+			board, err := getBoardTypeByCompatible(test.compatible)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, board)
+			assert.True(t, board.IsBoardType(boardtype.BeagleBoard))
+		})
+	}
+	_, err := getBoardTypeByCompatible([]string{"raspberrypi,5-model-b", "brcm,bcm2712"})
+	assert.Equal(t, ErrCannotIdentifyBoard, err)
+}
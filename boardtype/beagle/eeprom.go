@@ -0,0 +1,138 @@
+package beagle
+
+import (
+	"encoding/binary"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	tiEEPROMMagic            = 0xEE3355AA
+	legacyEEPROMSize         = 60
+	legacyEEPROMBoardName    = 4
+	legacyEEPROMVersion      = 12
+	legacyEEPROMSerial       = 16
+	legacyEEPROMConfig       = 28
+	k3EEPROMRecordBoardID    = 0x01
+	k3EEPROMRecordBoardInfo  = 0x10
+	k3EEPROMRecordEndList    = 0xFE
+	k3EEPROMBoardInfoSize    = 46
+	k3EEPROMRecordHeaderSize = 3
+)
+
+type EEPROMFormat int
+
+const (
+	EEPROMFormatLegacy EEPROMFormat = iota
+	EEPROMFormatK3
+)
+
+var (
+	ErrEEPROMNotFound = errors.New("board EEPROM not found")
+	ErrInvalidEEPROM  = errors.New("invalid board EEPROM")
+	eepromGlobs       = []string{
+		"/sys/bus/i2c/devices/*-0050/eeprom",
+		"/sys/bus/nvmem/devices/*-00500/nvmem",
+	}
+)
+
+// EEPROM is the TI board ID EEPROM, AM335x and AM57x boards use the legacy fixed layout and K3
+// boards (AM62, AM67A, TDA4VM) use a list of records after the same magic.
+type EEPROM struct {
+	Format          EEPROMFormat
+	BoardName       string
+	Version         string
+	Serial          string
+	Config          string
+	ProcessorNumber string
+	Variant         string
+	PCBRevision     string
+	BoardID         string
+}
+
+func GetEEPROM(logger *slog.Logger) (*EEPROM, error) {
+	paths := make([]string, 0)
+	for _, g := range eepromGlobs {
+		matches, _ := filepath.Glob(g)
+		paths = append(paths, matches...)
+	}
+	return readEEPROM(logger, paths)
+}
+
+func readEEPROM(logger *slog.Logger, paths []string) (*EEPROM, error) {
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			logger.Debug("cannot read board EEPROM", slog.String("path", path), slog.Any("error", err))
+			continue
+		}
+		eeprom, err := ParseEEPROM(data)
+		if err != nil {
+			logger.Debug("cannot parse board EEPROM", slog.String("path", path), slog.Any("error", err))
+			continue
+		}
+		logger.Debug("board EEPROM", slog.String("path", path), slog.String("name", eeprom.BoardName), slog.String("version", eeprom.Version), slog.String("serial", eeprom.Serial))
+		return eeprom, nil
+	}
+	return nil, ErrEEPROMNotFound
+}
+
+func ParseEEPROM(data []byte) (*EEPROM, error) {
+	if len(data) < legacyEEPROMSize || binary.LittleEndian.Uint32(data[0:4]) != tiEEPROMMagic {
+		return nil, ErrInvalidEEPROM
+	}
+	if data[4] == k3EEPROMRecordBoardID {
+		return parseK3EEPROM(data)
+	}
+	eeprom := &EEPROM{
+		Format:    EEPROMFormatLegacy,
+		BoardName: eepromString(data[legacyEEPROMBoardName:legacyEEPROMVersion]),
+		Version:   eepromString(data[legacyEEPROMVersion:legacyEEPROMSerial]),
+		Serial:    eepromString(data[legacyEEPROMSerial:legacyEEPROMConfig]),
+		Config:    eepromString(data[legacyEEPROMConfig:legacyEEPROMSize]),
+	}
+	if eeprom.BoardName == "" {
+		return nil, ErrInvalidEEPROM
+	}
+	return eeprom, nil
+}
+
+// parseK3EEPROM walks the records after the magic and the board ID header, each record is an
+// id byte followed by a little endian length.
+func parseK3EEPROM(data []byte) (*EEPROM, error) {
+	offset := 4 + k3EEPROMRecordHeaderSize
+	for offset+k3EEPROMRecordHeaderSize <= len(data) {
+		id := data[offset]
+		length := int(binary.LittleEndian.Uint16(data[offset+1 : offset+3]))
+		offset += k3EEPROMRecordHeaderSize
+		if id == k3EEPROMRecordEndList || offset+length > len(data) {
+			break
+		}
+		if id == k3EEPROMRecordBoardInfo && length >= k3EEPROMBoardInfoSize {
+			info := data[offset : offset+length]
+			eeprom := &EEPROM{
+				Format:          EEPROMFormatK3,
+				BoardName:       eepromString(info[0:16]),
+				Version:         eepromString(info[16:18]),
+				ProcessorNumber: eepromString(info[18:22]),
+				Variant:         eepromString(info[22:24]),
+				PCBRevision:     eepromString(info[24:26]),
+				BoardID:         eepromString(info[36:42]),
+				Serial:          eepromString(info[32:36]) + eepromString(info[36:42]) + eepromString(info[42:46]),
+			}
+			if eeprom.BoardName == "" {
+				return nil, ErrInvalidEEPROM
+			}
+			return eeprom, nil
+		}
+		offset += length
+	}
+	return nil, ErrInvalidEEPROM
+}
+
+func eepromString(b []byte) string {
+	return strings.TrimSpace(strings.TrimRight(string(b), "\x00\xff"))
+}
@@ -0,0 +1,100 @@
+package dmi
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+func init() {
+	identifier.RegisterBoardIdentifier(NewDMIIdentifier)
+}
+
+var (
+	ErrCannotIdentifyBoard = errors.New("cannot identify DMI board")
+)
+
+type dmiBoard struct {
+	Vendor string
+	Name   string
+	Type   boardtype.SBC
+}
+
+// Vendor is matched against the system and board vendor and Name is a prefix of the product or
+// board name, the first match wins so the longer names come first.
+var dmiBoards = []dmiBoard{
+	{"AAEON", "UP-CHT01", boardtype.UPBoard},
+	{"AAEON", "UP-CHT11", boardtype.UPCore},
+	{"AAEON", "UP-APL03", boardtype.UPCorePlus},
+	{"AAEON", "UP-APL01", boardtype.UPSquared},
+	{"AAEON", "UPN-EHL01", boardtype.UPSquared6000},
+	{"AAEON", "UP-WHL01", boardtype.UPXtreme},
+	{"AAEON", "UPX-TGL01", boardtype.UPXtremeI11},
+	{"LattePanda", "LattePanda Alpha", boardtype.LattePandaAlpha},
+	{"LattePanda", "LattePanda Delta", boardtype.LattePandaDelta},
+	{"LattePanda", "LattePanda Sigma", boardtype.LattePandaSigma},
+	{"LattePanda", "LattePanda Mu", boardtype.LattePandaMu},
+	{"Seeed", "ODYSSEY-X86J4105", boardtype.SeeedOdysseyX86J4105},
+	{"Seeed", "ODYSSEY-X86J4125", boardtype.SeeedOdysseyX86J4125},
+	{"Seeed", "ODYSSEY", boardtype.SeeedOdyssey},
+	{"Intel", "NUC", boardtype.IntelNUC},
+	{"ASUSTeK", "NUC", boardtype.IntelNUC},
+}
+
+var registeredBoards = make([]dmiBoard, 0)
+
+// RegisterBoard adds a board that isn't in the built in table, registered boards are checked
+// first so they can override it.
+func RegisterBoard(vendor string, name string, board boardtype.SBC) {
+	registeredBoards = append(registeredBoards, dmiBoard{vendor, name, board})
+}
+
+type dmiIdentifier struct {
+	logger *slog.Logger
+}
+
+func NewDMIIdentifier(logger *slog.Logger) identifier.BoardIdentifier {
+	logger.Debug("initializing DMI identifier")
+	newLogger := logger.With(slog.String("source", "DMI"))
+	return dmiIdentifier{
+		logger: newLogger,
+	}
+}
+
+func (r dmiIdentifier) Name() string {
+	return "DMI Identifier"
+}
+
+func (r dmiIdentifier) GetBoardType() (boardtype.SBC, error) {
+	info, err := identifier.GetDMIInfo(r.logger)
+	if err != nil {
+		return nil, ErrCannotIdentifyBoard
+	}
+	board, ok := getDMIBoard(info)
+	if !ok {
+		r.logger.Debug("DMI does not match any boards", slog.String("vendor", info.SysVendor), slog.String("product", info.ProductName), slog.String("board", info.BoardName))
+		return nil, ErrCannotIdentifyBoard
+	}
+	return board.Type, nil
+}
+
+func getDMIBoard(info *identifier.DMIInfo) (dmiBoard, bool) {
+	for _, boards := range [][]dmiBoard{registeredBoards, dmiBoards} {
+		for _, b := range boards {
+			if !hasPrefixFold(info.SysVendor, b.Vendor) && !hasPrefixFold(info.BoardVendor, b.Vendor) {
+				continue
+			}
+			if hasPrefixFold(info.ProductName, b.Name) || hasPrefixFold(info.BoardName, b.Name) {
+				return b, true
+			}
+		}
+	}
+	return dmiBoard{}, false
+}
+
+func hasPrefixFold(s string, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
@@ -0,0 +1,49 @@
+package dmi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+func TestGetDMIBoard(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     identifier.DMIInfo
+		expected boardtype.SBC
+		vendor   boardtype.SBC
+	}{
+		{"UP Squared", identifier.DMIInfo{SysVendor: "AAEON", ProductName: "UP-APL01", BoardVendor: "AAEON", BoardName: "UP-APL01"}, boardtype.UPSquared, boardtype.AAEON},
+		{"UP Xtreme i11", identifier.DMIInfo{SysVendor: "AAEON", ProductName: "UPX-TGL01", BoardVendor: "AAEON", BoardName: "UPX-TGL01"}, boardtype.UPXtremeI11, boardtype.UP},
+		{"LattePanda Sigma", identifier.DMIInfo{SysVendor: "LattePanda", ProductName: "LattePanda Sigma"}, boardtype.LattePandaSigma, boardtype.LattePanda},
+		{"Odyssey", identifier.DMIInfo{SysVendor: "Seeed Technology Co.,Ltd.", ProductName: "ODYSSEY-X86J4105"}, boardtype.SeeedOdysseyX86J4105, boardtype.Seeed},
+		{"Odyssey Blue", identifier.DMIInfo{SysVendor: "Seeed Technology Co.,Ltd.", ProductName: "ODYSSEY-BLUE"}, boardtype.SeeedOdyssey, boardtype.Seeed},
+		{"NUC", identifier.DMIInfo{SysVendor: "Intel Corporation", ProductName: "NUC8i5BEH", BoardVendor: "Intel Corporation", BoardName: "NUC8BEB"}, boardtype.IntelNUC, boardtype.Intel},
+		{"ASUS NUC", identifier.DMIInfo{SysVendor: "ASUSTeK COMPUTER INC.", ProductName: "NUC14RVH", BoardVendor: "ASUSTeK COMPUTER INC.", BoardName: "NUC14RVB"}, boardtype.IntelNUC, boardtype.Intel},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			board, ok := getDMIBoard(&test.info)
+			require.True(t, ok)
+			assert.Equal(t, test.expected, board.Type)
+			assert.True(t, board.Type.IsBoardType(test.vendor))
+		})
+	}
+	_, ok := getDMIBoard(&identifier.DMIInfo{SysVendor: "Dell Inc.", ProductName: "NUC-lookalike"})
+	assert.False(t, ok)
+	_, ok = getDMIBoard(&identifier.DMIInfo{SysVendor: "HARDKERNEL", ProductName: "ODROID-H3"})
+	assert.False(t, ok)
+}
+
+func TestRegisterBoard(t *testing.T) {
+	defer func() { registeredBoards = registeredBoards[:0] }()
+	info := &identifier.DMIInfo{SysVendor: "AAEON", ProductName: "UP-APL01"}
+	RegisterBoard("AAEON", "UP-APL01", boardtype.UP)
+	board, ok := getDMIBoard(info)
+	require.True(t, ok)
+	assert.Equal(t, boardtype.UP, board.Type)
+}
@@ -0,0 +1,28 @@
+package boardtype
+
+var (
+	Hardkernel     = BoardType{Manufacturer: "Hardkernel", Model: "", SubModel: "", RAM: 0}
+	ODROID         = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "", RAM: 0, BaseModel: &Hardkernel}
+	ODROIDAmlogic  = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "Amlogic", RAM: 0, BaseModel: &ODROID}
+	ODROIDC2       = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "C2", RAM: 2048, BaseModel: &ODROIDAmlogic}
+	ODROIDC4       = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "C4", RAM: 4096, BaseModel: &ODROIDAmlogic}
+	ODROIDHC4      = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "HC4", RAM: 4096, BaseModel: &ODROIDAmlogic}
+	ODROIDN2       = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "N2", RAM: 0, BaseModel: &ODROIDAmlogic}
+	ODROIDN2Plus   = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "N2+", RAM: 0, BaseModel: &ODROIDN2}
+	ODROIDN2L      = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "N2L", RAM: 0, BaseModel: &ODROIDN2}
+	ODROIDRockchip = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "Rockchip", RAM: 0, BaseModel: &ODROID}
+	ODROIDM1       = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "M1", RAM: 0, BaseModel: &ODROIDRockchip}
+	ODROIDM1S      = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "M1S", RAM: 0, BaseModel: &ODROIDRockchip}
+	ODROIDM2       = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "M2", RAM: 0, BaseModel: &ODROIDRockchip}
+	ODROIDExynos   = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "Exynos", RAM: 0, BaseModel: &ODROID}
+	ODROIDXU4      = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "XU4", RAM: 2048, BaseModel: &ODROIDExynos}
+	ODROIDHC1      = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "HC1", RAM: 2048, BaseModel: &ODROIDXU4}
+	ODROIDX86      = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "x86", RAM: 0, BaseModel: &ODROID}
+	ODROIDH2       = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "H2", RAM: 0, BaseModel: &ODROIDX86}
+	ODROIDH2Plus   = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "H2+", RAM: 0, BaseModel: &ODROIDH2}
+	ODROIDH3       = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "H3", RAM: 0, BaseModel: &ODROIDX86}
+	ODROIDH3Plus   = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "H3+", RAM: 0, BaseModel: &ODROIDH3}
+	ODROIDH4       = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "H4", RAM: 0, BaseModel: &ODROIDX86}
+	ODROIDH4Plus   = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "H4+", RAM: 0, BaseModel: &ODROIDH4}
+	ODROIDH4Ultra  = BoardType{Manufacturer: "Hardkernel", Model: "ODROID", SubModel: "H4 Ultra", RAM: 0, BaseModel: &ODROIDH4}
+)
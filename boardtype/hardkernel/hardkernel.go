@@ -0,0 +1,167 @@
+package hardkernel
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+func init() {
+	identifier.RegisterBoardIdentifier(NewHardkernelIdentifier)
+}
+
+var (
+	ErrCannotIdentifyBoard = errors.New("cannot identify Hardkernel board")
+)
+
+type odroid struct {
+	Model string
+	Type  boardtype.SBC
+}
+
+var odroidBoardsByCompatible = []odroid{
+	{"hardkernel,odroid-c2", boardtype.ODROIDC2},
+	{"hardkernel,odroid-c4", boardtype.ODROIDC4},
+	{"hardkernel,odroid-hc4", boardtype.ODROIDHC4},
+	{"hardkernel,odroid-n2-plus", boardtype.ODROIDN2Plus},
+	{"hardkernel,odroid-n2l", boardtype.ODROIDN2L},
+	{"hardkernel,odroid-n2", boardtype.ODROIDN2},
+	{"hardkernel,odroid-m1s", boardtype.ODROIDM1S},
+	{"hardkernel,odroid-m1", boardtype.ODROIDM1},
+	{"hardkernel,odroid-m2", boardtype.ODROIDM2},
+	{"hardkernel,odroid-hc1", boardtype.ODROIDHC1},
+	{"hardkernel,odroid-xu4", boardtype.ODROIDXU4},
+}
+
+// Vendor kernels don't always use the upstream compatible strings, the models are matched
+// case insensitively and the longer names come first.
+var odroidBoardsByDeviceTreeModel = []odroid{
+	{"ODROID-N2PLUS", boardtype.ODROIDN2Plus},
+	{"ODROID-N2+", boardtype.ODROIDN2Plus},
+	{"ODROID-N2L", boardtype.ODROIDN2L},
+	{"ODROID-N2", boardtype.ODROIDN2},
+	{"ODROID-HC4", boardtype.ODROIDHC4},
+	{"ODROID-C4", boardtype.ODROIDC4},
+	{"ODROID-C2", boardtype.ODROIDC2},
+	{"ODROID-M1S", boardtype.ODROIDM1S},
+	{"ODROID-M1", boardtype.ODROIDM1},
+	{"ODROID-M2", boardtype.ODROIDM2},
+	{"ODROID HC1", boardtype.ODROIDHC1},
+	{"ODROID XU4", boardtype.ODROIDXU4},
+}
+
+var odroidBoardsByDMIProductName = []odroid{
+	{"ODROID-H2+", boardtype.ODROIDH2Plus},
+	{"ODROID-H2", boardtype.ODROIDH2},
+	{"ODROID-H3+", boardtype.ODROIDH3Plus},
+	{"ODROID-H3", boardtype.ODROIDH3},
+	{"ODROID-H4 ULTRA", boardtype.ODROIDH4Ultra},
+	{"ODROID-H4+", boardtype.ODROIDH4Plus},
+	{"ODROID-H4", boardtype.ODROIDH4},
+}
+
+type hardkernelIdentifier struct {
+	logger *slog.Logger
+}
+
+func NewHardkernelIdentifier(logger *slog.Logger) identifier.BoardIdentifier {
+	logger.Debug("initializing Hardkernel identifier")
+	newLogger := logger.With(slog.String("source", "Hardkernel"))
+	return hardkernelIdentifier{
+		logger: newLogger,
+	}
+}
+
+func (r hardkernelIdentifier) Name() string {
+	return "Hardkernel Identifier"
+}
+
+type hardkernelSource struct {
+	Name         string
+	GetBoardType func(*slog.Logger) (boardtype.SBC, error)
+}
+
+var hardkernelSources = []hardkernelSource{
+	{"device tree compatible", getBoardTypeFromCompatible},
+	{"device tree model", getBoardTypeFromDeviceTreeModel},
+	{"DMI", getBoardTypeFromDMI},
+}
+
+func (r hardkernelIdentifier) GetBoardType() (boardtype.SBC, error) {
+	for _, source := range hardkernelSources {
+		boardType, err := source.GetBoardType(r.logger)
+		if err != nil {
+			r.logger.Debug("cannot identify board", slog.String("source", source.Name), slog.Any("error", err))
+			continue
+		}
+		r.logger.Debug("board type", slog.String("source", source.Name), slog.String("type", boardType.GetPrettyName()))
+		return boardType, nil
+	}
+	return nil, ErrCannotIdentifyBoard
+}
+
+func getBoardTypeFromCompatible(logger *slog.Logger) (boardtype.SBC, error) {
+	compatible, err := identifier.GetDeviceTreeCompatible(logger)
+	if err != nil {
+		return nil, err
+	}
+	return getBoardTypeByCompatible(compatible)
+}
+
+func getBoardTypeByCompatible(compatible []string) (boardtype.SBC, error) {
+	for _, c := range compatible {
+		for _, b := range odroidBoardsByCompatible {
+			if c == b.Model {
+				return b.Type, nil
+			}
+		}
+	}
+	return nil, ErrCannotIdentifyBoard
+}
+
+func getBoardTypeFromDeviceTreeModel(logger *slog.Logger) (boardtype.SBC, error) {
+	model, err := identifier.GetDeviceTreeModel(logger)
+	if err != nil {
+		return nil, err
+	}
+	return getBoardTypeByDeviceTreeModel(model)
+}
+
+func getBoardTypeByDeviceTreeModel(model string) (boardtype.SBC, error) {
+	model = strings.ToUpper(model)
+	if !strings.Contains(model, "HARDKERNEL") && !strings.Contains(model, "ODROID") {
+		return nil, ErrCannotIdentifyBoard
+	}
+	for _, b := range odroidBoardsByDeviceTreeModel {
+		if strings.Contains(model, b.Model) {
+			return b.Type, nil
+		}
+	}
+	return nil, ErrCannotIdentifyBoard
+}
+
+func getBoardTypeFromDMI(logger *slog.Logger) (boardtype.SBC, error) {
+	info, err := identifier.GetDMIInfo(logger)
+	if err != nil {
+		return nil, err
+	}
+	return getBoardTypeByDMI(info)
+}
+
+func getBoardTypeByDMI(info *identifier.DMIInfo) (boardtype.SBC, error) {
+	if !strings.EqualFold(info.SysVendor, "HARDKERNEL") && !strings.EqualFold(info.BoardVendor, "HARDKERNEL") {
+		return nil, ErrCannotIdentifyBoard
+	}
+	for _, name := range []string{info.ProductName, info.BoardName} {
+		name = strings.ToUpper(name)
+		for _, b := range odroidBoardsByDMIProductName {
+			if name == b.Model {
+				return b.Type, nil
+			}
+		}
+	}
+	return boardtype.ODROIDX86, nil
+}
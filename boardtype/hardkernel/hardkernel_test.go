@@ -0,0 +1,74 @@
+package hardkernel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+func TestGetBoardTypeByCompatible(t *testing.T) {
+	tests := []struct {
+		compatible []string
+		expected   boardtype.SBC
+		line       boardtype.SBC
+	}{
+		{[]string{"hardkernel,odroid-n2-plus", "amlogic,s922x", "amlogic,g12b"}, boardtype.ODROIDN2Plus, boardtype.ODROIDAmlogic},
+		{[]string{"hardkernel,odroid-c4", "amlogic,sm1"}, boardtype.ODROIDC4, boardtype.ODROIDAmlogic},
+		{[]string{"hardkernel,odroid-m1s", "rockchip,rk3566"}, boardtype.ODROIDM1S, boardtype.ODROIDRockchip},
+		{[]string{"hardkernel,odroid-m1", "rockchip,rk3568"}, boardtype.ODROIDM1, boardtype.ODROIDRockchip},
+		{[]string{"hardkernel,odroid-xu4", "samsung,exynos5800", "samsung,exynos5"}, boardtype.ODROIDXU4, boardtype.ODROIDExynos},
+	}
+	for _, test := range tests {
+		t.Run(test.compatible[0], func(t *testing.T) {
+			board, err := getBoardTypeByCompatible(test.compatible)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, board)
+			assert.True(t, board.IsBoardType(test.line))
+		})
+	}
+	_, err := getBoardTypeByCompatible([]string{"radxa,rock-5b", "rockchip,rk3588"})
+	assert.Equal(t, ErrCannotIdentifyBoard, err)
+}
+
+func TestGetBoardTypeByDeviceTreeModel(t *testing.T) {
+	tests := []struct {
+		model    string
+		expected boardtype.SBC
+	}{
+		{"Hardkernel ODROID-N2Plus", boardtype.ODROIDN2Plus},
+		{"Hardkernel ODROID-N2", boardtype.ODROIDN2},
+		{"Hardkernel Odroid XU4", boardtype.ODROIDXU4},
+		{"Hardkernel ODROID-M1S", boardtype.ODROIDM1S},
+	}
+	for _, test := range tests {
+		t.Run(test.model, func(t *testing.T) {
+			board, err := getBoardTypeByDeviceTreeModel(test.model)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, board)
+		})
+	}
+	_, err := getBoardTypeByDeviceTreeModel("Radxa ROCK 5B")
+	assert.Equal(t, ErrCannotIdentifyBoard, err)
+}
+
+func TestGetBoardTypeByDMI(t *testing.T) {
+	board, err := getBoardTypeByDMI(&identifier.DMIInfo{SysVendor: "HARDKERNEL", ProductName: "ODROID-H3+"})
+	require.NoError(t, err)
+	assert.Equal(t, boardtype.ODROIDH3Plus, board)
+	assert.True(t, board.IsBoardType(boardtype.ODROIDX86))
+
+	board, err = getBoardTypeByDMI(&identifier.DMIInfo{BoardVendor: "HARDKERNEL", ProductName: "Default string", BoardName: "ODROID-H4"})
+	require.NoError(t, err)
+	assert.Equal(t, boardtype.ODROIDH4, board)
+
+	board, err = getBoardTypeByDMI(&identifier.DMIInfo{SysVendor: "HARDKERNEL", ProductName: "ODROID-H5"})
+	require.NoError(t, err)
+	assert.Equal(t, boardtype.ODROIDX86, board)
+
+	_, err = getBoardTypeByDMI(&identifier.DMIInfo{SysVendor: "Intel Corporation", ProductName: "NUC13ANHi5"})
+	assert.Equal(t, ErrCannotIdentifyBoard, err)
+}
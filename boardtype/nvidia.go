@@ -27,6 +27,8 @@ var (
 	JetsonAGXXavierIndustrial32GB = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "AGX Xavier Industrial", RAM: 32768, BaseModel: &JetsonAGXXavier}
 	JetsonNano                    = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Nano", RAM: 0, BaseModel: &Jetson}
 	JetsonNanoDeveloperKit        = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Nano Developer Kit", RAM: 0, BaseModel: &JetsonNano}
+	JetsonNanoDeveloperKit2GB     = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Nano Developer Kit", RAM: 2048, BaseModel: &JetsonNanoDeveloperKit}
+	JetsonNanoDeveloperKit4GB     = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Nano Developer Kit", RAM: 4096, BaseModel: &JetsonNanoDeveloperKit}
 	JetsonNano2GB                 = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Nano", RAM: 2048, BaseModel: &JetsonNano}
 	JetsonNano16GbEMMC            = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Nano", RAM: 0, BaseModel: &JetsonNano}
 	JetsonNano4GB                 = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Nano", RAM: 4096, BaseModel: &JetsonNano}
@@ -35,6 +37,27 @@ var (
 	JetsonTX2i                    = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "TX2i", RAM: 0, BaseModel: &JetsonTX2}
 	JetsonTX2                     = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "TX2", RAM: 0, BaseModel: &Jetson}
 	JetsonTX1                     = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "TX1", RAM: 0, BaseModel: &Jetson}
+	JetsonCarrier                 = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Carrier", RAM: 0, BaseModel: &NVIDIA}
+	JetsonOrinNanoCarrier         = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Orin Nano Developer Kit Carrier", RAM: 0, BaseModel: &JetsonCarrier}
+	JetsonAGXOrinCarrier          = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "AGX Orin Developer Kit Carrier", RAM: 0, BaseModel: &JetsonCarrier}
+	JetsonXavierNXCarrier         = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Xavier NX Developer Kit Carrier", RAM: 0, BaseModel: &JetsonCarrier}
+	JetsonAGXXavierCarrier        = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "AGX Xavier Developer Kit Carrier", RAM: 0, BaseModel: &JetsonCarrier}
+	JetsonNanoCarrier             = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Nano Developer Kit Carrier", RAM: 0, BaseModel: &JetsonCarrier}
+	JetsonTX2Carrier              = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "TX2 Developer Kit Carrier", RAM: 0, BaseModel: &JetsonCarrier}
 	ClaraAGX                      = BoardType{Manufacturer: "NVIDIA", Model: "Clara", SubModel: "AGX", RAM: 0, BaseModel: &NVIDIA}
 	ShieldTV                      = BoardType{Manufacturer: "NVIDIA", Model: "Shield", SubModel: "TV", RAM: 0, BaseModel: &NVIDIA}
 )
+
+var (
+	JetsonThor                      = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Thor", RAM: 0, BaseModel: &Jetson}
+	JetsonAGXThor                   = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "AGX Thor", RAM: 0, BaseModel: &JetsonThor}
+	JetsonAGXThorT4000              = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "AGX Thor T4000", RAM: 65536, BaseModel: &JetsonAGXThor}
+	JetsonAGXThorT5000              = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "AGX Thor T5000", RAM: 131072, BaseModel: &JetsonAGXThor}
+	JetsonAGXThorDeveloperKit       = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "AGX Thor Developer Kit", RAM: 131072, BaseModel: &JetsonAGXThorT5000}
+	JetsonAGXThorCarrier            = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "AGX Thor Developer Kit Carrier", RAM: 0, BaseModel: &JetsonCarrier}
+	JetsonOrinNanoSuperDeveloperKit = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Orin Nano Super Developer Kit", RAM: 8192, BaseModel: &JetsonOrinNanoDeveloperKit}
+	JetsonOrinNano8GBSuper          = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Orin Nano Super", RAM: 8192, BaseModel: &JetsonOrinNano8GB}
+	JetsonOrinNano4GBSuper          = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Orin Nano Super", RAM: 4096, BaseModel: &JetsonOrinNano4GB}
+	JetsonOrinNX16GBSuper           = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Orin NX Super", RAM: 16384, BaseModel: &JetsonOrinNX16GB}
+	JetsonOrinNX8GBSuper            = BoardType{Manufacturer: "NVIDIA", Model: "Jetson", SubModel: "Orin NX Super", RAM: 8192, BaseModel: &JetsonOrinNX8GB}
+)
@@ -0,0 +1,159 @@
+package nvidia
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+var jetsonCarriersByModelNumber = []jetson{
+	{"p3971-0000", boardtype.JetsonAGXThorCarrier},
+	{"p3768-0000", boardtype.JetsonOrinNanoCarrier},
+	{"p3737-0000", boardtype.JetsonAGXOrinCarrier},
+	{"p3509-0000", boardtype.JetsonXavierNXCarrier},
+	{"p2822-0000", boardtype.JetsonAGXXavierCarrier},
+	{"p3449-0000", boardtype.JetsonNanoCarrier},
+	{"p2597-0000", boardtype.JetsonTX2Carrier},
+}
+
+var registeredCarriers = make([]jetson, 0)
+
+// RegisterCarrierBoard adds a third party carrier, model is matched against the DTS filename,
+// the carrier EEPROM part number and the device tree model.
+func RegisterCarrierBoard(model string, carrier boardtype.SBC) {
+	registeredCarriers = append(registeredCarriers, jetson{model, carrier})
+}
+
+type JetsonPart struct {
+	Model      string
+	PartNumber string
+	Revision   string
+	Type       boardtype.SBC
+}
+
+// JetsonSystem describes the module and carrier, RAMFromMemInfo is set when the module RAM was
+// worked out from MemTotal because the module number doesn't identify it.
+type JetsonSystem struct {
+	Module         JetsonPart
+	Carrier        JetsonPart
+	SoC            string
+	RAMFromMemInfo bool
+}
+
+// GetJetsonSystem prefers the ID EEPROMs, then the device tree compatible list and finally the
+// DTS filename for each of the module and the carrier.
+func GetJetsonSystem(logger *slog.Logger) (*JetsonSystem, error) {
+	system := &JetsonSystem{}
+	var moduleName string
+	if dtsFilename, err := getDtsFile(logger); err == nil {
+		moduleName, _ = getModuleNameFromDtsFilename(logger, dtsFilename)
+		module, carrier, revision := parseModuleName(logger, moduleName)
+		system.Module.Model = module
+		system.Carrier.Model = carrier
+		system.Carrier.Revision = revision
+	}
+	if compatible, err := identifier.GetDeviceTreeCompatible(logger); err == nil {
+		c := parseJetsonCompatible(logger, compatible)
+		if c.Module != "" {
+			system.Module.Model = c.Module
+		}
+		if c.Carrier != "" {
+			system.Carrier.Model = c.Carrier
+		}
+		system.SoC = c.SoC
+	}
+	if eeprom, err := GetModuleEEPROM(logger); err == nil {
+		if model, err := eeprom.ModuleModel(); err == nil {
+			system.Module.Model = model
+		}
+		system.Module.PartNumber = eeprom.PartNumber
+		system.Module.Revision = eeprom.Revision
+	}
+	if eeprom, err := GetCarrierEEPROM(logger); err == nil {
+		if model, err := eeprom.ModuleModel(); err == nil {
+			system.Carrier.Model = model
+		}
+		system.Carrier.PartNumber = eeprom.PartNumber
+		system.Carrier.Revision = eeprom.Revision
+	}
+	for _, m := range jetsonModulesByModelNumber {
+		if system.Module.Model != "" && m.Model == system.Module.Model {
+			system.Module.Type = m.Type
+			break
+		}
+	}
+	dtbm, _ := identifier.GetDeviceTreeBaseModel(logger)
+	system.Carrier.Type = getCarrierType(system.Carrier, moduleName, dtbm)
+	if system.Module.Type == nil {
+		logger.Debug("cannot identify module", slog.String("model", system.Module.Model))
+		return nil, ErrCannotIdentifyBoard
+	}
+	system.Module.Type, system.RAMFromMemInfo = resolveJetsonRAM(logger, system.Module.Type)
+	system.Module.Type = resolveJetsonSuper(logger, system.Module.Type)
+	return system, nil
+}
+
+func getCarrierType(carrier JetsonPart, moduleName string, dtbm string) boardtype.SBC {
+	for _, c := range registeredCarriers {
+		if (moduleName != "" && strings.Contains(moduleName, c.Model)) || (carrier.PartNumber != "" && strings.Contains(carrier.PartNumber, c.Model)) || (dtbm != "" && strings.Contains(dtbm, c.Model)) {
+			return c.Type
+		}
+	}
+	for _, c := range jetsonCarriersByModelNumber {
+		if carrier.Model == c.Model {
+			return c.Type
+		}
+	}
+	return nil
+}
+
+// parseModuleName splits a DTS module name such as tegra234-p3767-0003-p3768-0000-a0 into
+// the module (p3767-0003), the carrier (p3768-0000) and the board revision (a0). Newer names
+// are carrier+module, tegra234-p3768-0000+p3767-0005-nv, and carry no revision.
+func parseModuleName(logger *slog.Logger, moduleName string) (string, string, string) {
+	if before, after, ok := strings.Cut(moduleName, "+"); ok {
+		var carrier string
+		if parts := strings.Split(before, "-"); len(parts) >= 2 {
+			carrier = trimPartNumber(strings.Join(parts[len(parts)-2:], "-"))
+		}
+		module := trimPartNumber(after)
+		logger.Debug("parsed module name", slog.String("module", module), slog.String("carrier", carrier))
+		return module, carrier, ""
+	}
+	parts := strings.Split(moduleName, "-")
+	models := make([]string, 0)
+	last := 0
+	for i := 0; i+1 < len(parts); i++ {
+		if isPartNumber(parts[i]) && (len(parts[i+1]) == 4 || parts[i+1] == "all") {
+			models = append(models, parts[i]+"-"+parts[i+1])
+			last = i + 2
+			i++
+		}
+	}
+	var module, carrier, revision string
+	if len(models) > 0 {
+		module = models[0]
+	}
+	if len(models) > 1 {
+		carrier = models[1]
+	}
+	if carrier != "" && last < len(parts) {
+		revision = parts[last]
+	}
+	logger.Debug("parsed module name", slog.String("module", module), slog.String("carrier", carrier), slog.String("revision", revision))
+	return module, carrier, revision
+}
+
+func isPartNumber(s string) bool {
+	if len(s) != 5 || s[0] != 'p' {
+		return false
+	}
+	for _, c := range s[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
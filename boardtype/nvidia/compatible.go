@@ -0,0 +1,78 @@
+package nvidia
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+type jetsonCompatible struct {
+	Module  string
+	Carrier string
+	SoC     string
+}
+
+// parseJetsonCompatible reads the module, carrier and SoC from the device tree compatible list,
+// NVIDIA names boards carrier+module, for example nvidia,p3768-0000+p3767-0005 and nvidia,tegra234.
+func parseJetsonCompatible(logger *slog.Logger, compatible []string) jetsonCompatible {
+	ret := jetsonCompatible{}
+	for _, c := range compatible {
+		vendor, name, ok := strings.Cut(c, ",")
+		if !ok || vendor != "nvidia" {
+			continue
+		}
+		if strings.HasPrefix(name, "tegra") && ret.SoC == "" {
+			ret.SoC = name
+			continue
+		}
+		if ret.Module != "" {
+			continue
+		}
+		parts := strings.Split(name, "+")
+		models := make([]string, 0, len(parts))
+		for _, p := range parts {
+			if model := trimPartNumber(p); model != "" {
+				models = append(models, model)
+			}
+		}
+		switch len(models) {
+		case 1:
+			ret.Module = models[0]
+		case 2:
+			ret.Carrier = models[0]
+			ret.Module = models[1]
+		}
+	}
+	logger.Debug("parsed compatible", slog.String("module", ret.Module), slog.String("carrier", ret.Carrier), slog.String("soc", ret.SoC))
+	return ret
+}
+
+// trimPartNumber reduces p3449-0000-b00 to p3449-0000, anything that isn't a part number is
+// returned as an empty string.
+func trimPartNumber(s string) string {
+	parts := strings.Split(s, "-")
+	if len(parts) < 2 || !isPartNumber(parts[0]) || len(parts[1]) != 4 {
+		return ""
+	}
+	return parts[0] + "-" + parts[1]
+}
+
+func getBoardTypeFromCompatible(logger *slog.Logger) (boardtype.SBC, error) {
+	compatible, err := identifier.GetDeviceTreeCompatible(logger)
+	if err != nil {
+		return nil, err
+	}
+	module := parseJetsonCompatible(logger, compatible).Module
+	if module == "" {
+		return nil, identifier.ErrCannotIdentifyBoard
+	}
+	for _, m := range jetsonModulesByModelNumber {
+		if m.Model == module {
+			return m.Type, nil
+		}
+	}
+	logger.Debug("compatible module does not match any boards", slog.String("module", module))
+	return nil, identifier.ErrCannotIdentifyBoard
+}
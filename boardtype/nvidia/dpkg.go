@@ -0,0 +1,56 @@
+package nvidia
+
+import (
+	"bufio"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	dpkgStatusFile = "var/lib/dpkg/status"
+)
+
+// getInstalledPackages returns the version of every installed package in the dpkg status
+// file under root.
+func getInstalledPackages(logger *slog.Logger, root string) (map[string]string, error) {
+	f, err := os.Open(filepath.Join(root, dpkgStatusFile))
+	if err != nil {
+		logger.Debug("cannot open dpkg status", slog.Any("error", err))
+		return nil, err
+	}
+	defer f.Close()
+
+	packages := make(map[string]string)
+	var name, status, version string
+	flush := func() {
+		if name != "" && strings.HasSuffix(status, " installed") {
+			packages[name] = version
+		}
+		name, status, version = "", "", ""
+	}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			flush()
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.HasPrefix(line, " ") {
+			continue
+		}
+		switch key {
+		case "Package":
+			name = strings.TrimSpace(value)
+		case "Status":
+			status = strings.TrimSpace(value)
+		case "Version":
+			version = strings.TrimSpace(value)
+		}
+	}
+	flush()
+	return packages, scanner.Err()
+}
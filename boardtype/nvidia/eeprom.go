@@ -0,0 +1,188 @@
+package nvidia
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+const (
+	moduleEEPROMSize         = 256
+	moduleEEPROMPartNumber   = 20
+	moduleEEPROMPartNumEnd   = 50
+	moduleEEPROMWiFiMAC      = 50
+	moduleEEPROMBluetoothMAC = 56
+	moduleEEPROMEthernetMAC  = 68
+	moduleEEPROMSerialNumber = 74
+	moduleEEPROMSerialEnd    = 90
+	moduleEEPROMCRC          = 255
+)
+
+var (
+	ErrModuleEEPROMNotFound = errors.New("module EEPROM not found")
+	ErrInvalidModuleEEPROM  = errors.New("invalid module EEPROM")
+	ErrModuleEEPROMCRC      = errors.New("module EEPROM CRC mismatch")
+	moduleEEPROMPaths       = []string{
+		"/sys/bus/i2c/devices/0-0050/eeprom",
+		"/sys/bus/nvmem/devices/0-00500/nvmem",
+		"/sys/bus/i2c/devices/1-0050/eeprom",
+		"/sys/bus/nvmem/devices/1-00500/nvmem",
+		"/sys/bus/i2c/devices/2-0050/eeprom",
+		"/sys/bus/nvmem/devices/2-00500/nvmem",
+	}
+	carrierEEPROMPaths = []string{
+		"/sys/bus/i2c/devices/0-0057/eeprom",
+		"/sys/bus/nvmem/devices/0-00570/nvmem",
+		"/sys/bus/i2c/devices/1-0057/eeprom",
+		"/sys/bus/nvmem/devices/1-00570/nvmem",
+		"/sys/bus/i2c/devices/2-0057/eeprom",
+		"/sys/bus/nvmem/devices/2-00570/nvmem",
+	}
+)
+
+// ModuleEEPROM is the ID EEPROM on Jetson modules, the part number looks like 699-13767-0005-300 K.2
+// where 3767 is the board, 0005 the SKU and K.2 the revision.
+type ModuleEEPROM struct {
+	Version      uint16
+	BoardID      uint16
+	SKU          uint16
+	FAB          uint8
+	PartNumber   string
+	Revision     string
+	SerialNumber string
+	WiFiMAC      net.HardwareAddr
+	BluetoothMAC net.HardwareAddr
+	EthernetMAC  net.HardwareAddr
+}
+
+// ModuleModel returns the module in the pNNNN-NNNN form used by the DTS filenames.
+func (e ModuleEEPROM) ModuleModel() (string, error) {
+	parts := strings.Split(e.PartNumber, "-")
+	if len(parts) < 3 || len(parts[1]) < 4 || len(parts[2]) != 4 {
+		return "", ErrInvalidModuleEEPROM
+	}
+	board := parts[1][len(parts[1])-4:]
+	return "p" + board + "-" + parts[2], nil
+}
+
+func GetModuleEEPROM(logger *slog.Logger) (*ModuleEEPROM, error) {
+	return readModuleEEPROM(logger, moduleEEPROMPaths)
+}
+
+// GetCarrierEEPROM reads the ID EEPROM of NVIDIA carrier boards, which use the module layout.
+func GetCarrierEEPROM(logger *slog.Logger) (*ModuleEEPROM, error) {
+	return readModuleEEPROM(logger, carrierEEPROMPaths)
+}
+
+func readModuleEEPROM(logger *slog.Logger, paths []string) (*ModuleEEPROM, error) {
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			logger.Debug("cannot read module EEPROM", slog.String("path", path), slog.Any("error", err))
+			continue
+		}
+		eeprom, err := ParseModuleEEPROM(data)
+		if err != nil {
+			logger.Debug("cannot parse module EEPROM", slog.String("path", path), slog.Any("error", err))
+			continue
+		}
+		logger.Debug("module EEPROM", slog.String("path", path), slog.String("partNumber", eeprom.PartNumber), slog.String("serialNumber", eeprom.SerialNumber))
+		return eeprom, nil
+	}
+	return nil, ErrModuleEEPROMNotFound
+}
+
+func ParseModuleEEPROM(data []byte) (*ModuleEEPROM, error) {
+	if len(data) < moduleEEPROMSize {
+		return nil, ErrInvalidModuleEEPROM
+	}
+	partNumber := eepromString(data[moduleEEPROMPartNumber:moduleEEPROMPartNumEnd])
+	if !strings.HasPrefix(partNumber, "699-") && !strings.HasPrefix(partNumber, "900-") {
+		return nil, ErrInvalidModuleEEPROM
+	}
+	if crc := eepromCRC8(data[:moduleEEPROMCRC]); crc != data[moduleEEPROMCRC] {
+		return nil, ErrModuleEEPROMCRC
+	}
+	eeprom := &ModuleEEPROM{
+		Version:      binary.LittleEndian.Uint16(data[0:2]),
+		BoardID:      binary.LittleEndian.Uint16(data[4:6]),
+		SKU:          binary.LittleEndian.Uint16(data[6:8]),
+		FAB:          data[8],
+		PartNumber:   partNumber,
+		SerialNumber: eepromString(data[moduleEEPROMSerialNumber:moduleEEPROMSerialEnd]),
+		WiFiMAC:      eepromMAC(data[moduleEEPROMWiFiMAC : moduleEEPROMWiFiMAC+6]),
+		BluetoothMAC: eepromMAC(data[moduleEEPROMBluetoothMAC : moduleEEPROMBluetoothMAC+6]),
+		EthernetMAC:  eepromMAC(data[moduleEEPROMEthernetMAC : moduleEEPROMEthernetMAC+6]),
+	}
+	if fields := strings.Fields(partNumber); len(fields) == 2 {
+		eeprom.PartNumber = fields[0]
+		eeprom.Revision = fields[1]
+	}
+	return eeprom, nil
+}
+
+func getBoardTypeFromModuleEEPROM(logger *slog.Logger) (boardtype.SBC, error) {
+	eeprom, err := GetModuleEEPROM(logger)
+	if err != nil {
+		return nil, err
+	}
+	moduleModel, err := eeprom.ModuleModel()
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("module model", slog.String("model", moduleModel))
+	for _, m := range jetsonModulesByModelNumber {
+		if m.Model == moduleModel {
+			return m.Type, nil
+		}
+	}
+	return nil, identifier.ErrCannotIdentifyBoard
+}
+
+// eepromCRC8 is the CRC-8 in the last byte of the EEPROM, polynomial x^8+x^5+x^4+1 shifted
+// least significant bit first (0x8c) with an initial value of 0.
+func eepromCRC8(b []byte) uint8 {
+	var crc uint8
+	for _, v := range b {
+		crc ^= v
+		for i := 0; i < 8; i++ {
+			if crc&0x01 != 0 {
+				crc = crc>>1 ^ 0x8c
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return crc
+}
+
+func eepromString(b []byte) string {
+	return strings.TrimSpace(strings.TrimRight(string(b), "\x00\xff"))
+}
+
+// MACs are stored least significant byte first, unprogrammed ones are all 0x00 or 0xff.
+func eepromMAC(b []byte) net.HardwareAddr {
+	blank := true
+	mac := make(net.HardwareAddr, len(b))
+	for i := range b {
+		mac[len(b)-1-i] = b[i]
+		if b[i] != 0x00 && b[i] != 0xff {
+			blank = false
+		}
+	}
+	if blank {
+		return nil
+	}
+	return mac
+}
+
+func (e ModuleEEPROM) String() string {
+	return fmt.Sprintf("%s %s (serial %s)", e.PartNumber, e.Revision, e.SerialNumber)
+}
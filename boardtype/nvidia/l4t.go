@@ -0,0 +1,157 @@
+package nvidia
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	nvTegraReleaseFile = "etc/nv_tegra_release"
+	l4tCorePackage     = "nvidia-l4t-core"
+)
+
+var (
+	ErrL4TNotFound       = errors.New("L4T release not found")
+	ErrInvalidL4TRelease = errors.New("invalid L4T release")
+	ErrUnknownJetPack    = errors.New("no JetPack release for L4T version")
+)
+
+type jetPackRelease struct {
+	L4T     Version
+	JetPack Version
+	Name    string
+}
+
+var jetPackReleases = []jetPackRelease{
+	{Version{32, 4, 3, 0}, Version{4, 4, 0, 0}, "4.4"},
+	{Version{32, 4, 4, 0}, Version{4, 4, 1, 0}, "4.4.1"},
+	{Version{32, 5, 0, 0}, Version{4, 5, 0, 0}, "4.5"},
+	{Version{32, 5, 1, 0}, Version{4, 5, 1, 0}, "4.5.1"},
+	{Version{32, 5, 2, 0}, Version{4, 5, 1, 0}, "4.5.1"},
+	{Version{32, 6, 1, 0}, Version{4, 6, 0, 0}, "4.6"},
+	{Version{32, 7, 1, 0}, Version{4, 6, 1, 0}, "4.6.1"},
+	{Version{32, 7, 2, 0}, Version{4, 6, 2, 0}, "4.6.2"},
+	{Version{32, 7, 3, 0}, Version{4, 6, 3, 0}, "4.6.3"},
+	{Version{32, 7, 4, 0}, Version{4, 6, 4, 0}, "4.6.4"},
+	{Version{32, 7, 5, 0}, Version{4, 6, 5, 0}, "4.6.5"},
+	{Version{32, 7, 6, 0}, Version{4, 6, 6, 0}, "4.6.6"},
+	{Version{34, 1, 0, 0}, Version{5, 0, 0, 0}, "5.0 DP"},
+	{Version{34, 1, 1, 0}, Version{5, 0, 1, 0}, "5.0.1 DP"},
+	{Version{35, 1, 0, 0}, Version{5, 0, 2, 0}, "5.0.2"},
+	{Version{35, 2, 1, 0}, Version{5, 1, 0, 0}, "5.1"},
+	{Version{35, 3, 1, 0}, Version{5, 1, 1, 0}, "5.1.1"},
+	{Version{35, 4, 1, 0}, Version{5, 1, 2, 0}, "5.1.2"},
+	{Version{35, 5, 0, 0}, Version{5, 1, 3, 0}, "5.1.3"},
+	{Version{35, 6, 0, 0}, Version{5, 1, 4, 0}, "5.1.4"},
+	{Version{35, 6, 1, 0}, Version{5, 1, 5, 0}, "5.1.5"},
+	{Version{36, 2, 0, 0}, Version{6, 0, 0, 0}, "6.0 DP"},
+	{Version{36, 3, 0, 0}, Version{6, 0, 0, 0}, "6.0"},
+	{Version{36, 4, 0, 0}, Version{6, 1, 0, 0}, "6.1"},
+	{Version{36, 4, 3, 0}, Version{6, 2, 0, 0}, "6.2"},
+	{Version{36, 4, 4, 0}, Version{6, 2, 1, 0}, "6.2.1"},
+	{Version{38, 2, 0, 0}, Version{7, 0, 0, 0}, "7.0"},
+}
+
+type L4TRelease struct {
+	Version     Version
+	Board       string
+	GCID        string
+	EABI        string
+	Date        time.Time
+	JetPack     Version
+	JetPackName string
+}
+
+func GetL4TRelease(logger *slog.Logger) (*L4TRelease, error) {
+	return readL4TRelease(logger, "/")
+}
+
+func readL4TRelease(logger *slog.Logger, root string) (*L4TRelease, error) {
+	var release *L4TRelease
+	c, err := os.ReadFile(filepath.Join(root, nvTegraReleaseFile))
+	if err == nil {
+		release, err = parseNvTegraRelease(logger, string(c))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		logger.Debug("cannot read nv_tegra_release, falling back to dpkg", slog.Any("error", err))
+		packages, err := getInstalledPackages(logger, root)
+		if err != nil {
+			return nil, ErrL4TNotFound
+		}
+		pkgVersion, ok := packages[l4tCorePackage]
+		if !ok {
+			logger.Debug("L4T core package is not installed")
+			return nil, ErrL4TNotFound
+		}
+		version, err := ParseVersion(pkgVersion)
+		if err != nil {
+			return nil, ErrInvalidL4TRelease
+		}
+		release = &L4TRelease{Version: version}
+	}
+	if jetPack, err := getJetPackRelease(release.Version); err == nil {
+		release.JetPack = jetPack.JetPack
+		release.JetPackName = jetPack.Name
+	} else {
+		logger.Debug("no JetPack release matches L4T version", slog.String("version", release.Version.String()))
+	}
+	return release, nil
+}
+
+// parseNvTegraRelease parses the first line of /etc/nv_tegra_release, for example
+// # R35 (release), REVISION: 4.1, GCID: 33958178, BOARD: t186ref, EABI: aarch64, DATE: Tue Aug  1 19:57:35 UTC 2023
+func parseNvTegraRelease(logger *slog.Logger, content string) (*L4TRelease, error) {
+	line, _, _ := strings.Cut(content, "\n")
+	line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#"))
+	logger.Debug("nv_tegra_release", slog.String("line", line))
+	release := &L4TRelease{}
+	var major, revision string
+	for i, field := range strings.Split(line, ",") {
+		field = strings.TrimSpace(field)
+		if i == 0 {
+			major, _, _ = strings.Cut(field, " ")
+			continue
+		}
+		key, value, ok := strings.Cut(field, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "REVISION":
+			revision = value
+		case "GCID":
+			release.GCID = value
+		case "BOARD":
+			release.Board = value
+		case "EABI":
+			release.EABI = value
+		case "DATE":
+			if date, err := time.Parse(time.UnixDate, value); err == nil {
+				release.Date = date
+			}
+		}
+	}
+	version, err := ParseVersion(major + "." + revision)
+	if err != nil || !strings.HasPrefix(major, "R") || revision == "" {
+		logger.Debug("cannot parse L4T version", slog.String("release", major), slog.String("revision", revision))
+		return nil, ErrInvalidL4TRelease
+	}
+	release.Version = version
+	return release, nil
+}
+
+func getJetPackRelease(l4t Version) (jetPackRelease, error) {
+	for _, r := range jetPackReleases {
+		if r.L4T == l4t {
+			return r, nil
+		}
+	}
+	return jetPackRelease{}, ErrUnknownJetPack
+}
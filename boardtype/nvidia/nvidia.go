@@ -38,6 +38,9 @@ var jetsonModulesByModelNumber = []jetson{
 	{"p3767-0004", boardtype.JetsonOrinNano4GB},
 	{"p3767-0005", boardtype.JetsonOrinNanoDeveloperKit},
 
+	{"p3834-0004", boardtype.JetsonAGXThorT4000},
+	{"p3834-0008", boardtype.JetsonAGXThorT5000},
+
 	{"p3701-0000", boardtype.JetsonAGXOrin},
 	{"p3701-0004", boardtype.JetsonAGXOrin32GB},
 	{"p3701-0005", boardtype.JetsonAGXOrin64GB},
@@ -62,7 +65,6 @@ var jetsonModulesByModelNumber = []jetson{
 	{"p3450-0000", boardtype.JetsonNanoDeveloperKit},
 
 	{"p3636-0001", boardtype.JetsonTX2NX},
-	{"p3509-0000", boardtype.JetsonTX2NX},
 
 	{"p3489-0888", boardtype.JetsonTX24GB},
 	{"p3489-0000", boardtype.JetsonTX2i},
@@ -77,6 +79,11 @@ var jetsonModulesByModelNumber = []jetson{
 }
 
 var jetsonModulesByDeviceTreeBaseModel = []jetson{
+	{"NVIDIA Jetson AGX Thor Developer Kit", boardtype.JetsonAGXThorDeveloperKit},
+	{"NVIDIA Jetson AGX Thor", boardtype.JetsonAGXThor},
+	{"NVIDIA Jetson Orin NX Engineering Reference Developer Kit Super", boardtype.JetsonOrinNX16GBSuper},
+	{"NVIDIA Jetson Orin Nano Engineering Reference Developer Kit Super", boardtype.JetsonOrinNanoSuperDeveloperKit},
+	{"NVIDIA Jetson Orin Nano Engineering Reference Developer Kit", boardtype.JetsonOrinNanoDeveloperKit},
 	{"NVIDIA Jetson Orin NX Engineering Reference Developer Kit", boardtype.JetsonOrinNX16GB},
 	{"NVIDIA Jetson Orin Nano Developer Kit", boardtype.JetsonOrinNanoDeveloperKit},
 	{"NVIDIA Jetson TX2 Developer Kit", boardtype.JetsonTX2},
@@ -87,7 +94,7 @@ var jetsonModulesByDeviceTreeBaseModel = []jetson{
 	{"NVIDIA Jetson Xavier NX Developer Kit (SD-card)", boardtype.JetsonXavierNXDeveloperKit},
 	{"NVIDIA Jetson Xavier NX Developer Kit (eMMC)", boardtype.JetsonXavierNXDeveloperKit},
 	{"NVIDIA Jetson Xavier NX (SD-card)", boardtype.JetsonXavierNXDeveloperKit},
-	{"NVIDIA Jetson Xavier NX (eMMC)", boardtype.JetsonXavierNX8GB},
+	{"NVIDIA Jetson Xavier NX (eMMC)", boardtype.JetsonXavierNX},
 	{"NVIDIA Jetson TX1", boardtype.JetsonTX1},
 	{"NVIDIA Jetson TX1 Developer Kit", boardtype.JetsonTX1},
 	{"NVIDIA Shield TV", boardtype.ShieldTV},
@@ -112,41 +119,37 @@ func (r jetsonIdentifier) Name() string {
 	return "Jetson Identifier"
 }
 
+type jetsonSource struct {
+	Name         string
+	GetBoardType func(*slog.Logger) (boardtype.SBC, error)
+}
+
+// The module EEPROM reflects what is physically installed but usually needs root, the device tree
+// compatible list is the primary signal after that.
+var jetsonSources = []jetsonSource{
+	{"module EEPROM", getBoardTypeFromModuleEEPROM},
+	{"device tree compatible", getBoardTypeFromCompatible},
+	{"DTS file", getBoardTypeFromModuleModel},
+	{"device tree base model", getBoardTypeByDeviceTreeBaseModel},
+}
+
 func (r jetsonIdentifier) GetBoardType() (boardtype.SBC, error) {
-	boardType, err := getBoardTypeFromModuleModel(r.logger)
-	if err == ErrDtsFileDoesNotExist {
-		r.logger.Debug("DTS file does not exist, falling back to device tree base model")
-		boardType, err = getBoardTypeByDeviceTreeBaseModel(r.logger)
-		if err == identifier.ErrCannotIdentifyBoard {
-			r.logger.Debug("unknown board")
-			return nil, ErrCannotIdentifyBoard
-		} else if err != nil {
-			r.logger.Debug("error getting board type", slog.Any("error", err))
-			return nil, err
-		} else {
-			r.logger.Debug("board type", slog.String("type", string(boardType.GetPrettyName())))
-			return boardType, nil
+	for _, source := range jetsonSources {
+		boardType, err := source.GetBoardType(r.logger)
+		if err != nil {
+			r.logger.Debug("cannot identify board", slog.String("source", source.Name), slog.Any("error", err))
+			continue
 		}
-	} else if err == identifier.ErrCannotIdentifyBoard {
-		r.logger.Debug("unknown board, falling back to device tree base model")
-		boardType, err = getBoardTypeByDeviceTreeBaseModel(r.logger)
-		if err == identifier.ErrCannotIdentifyBoard {
-			r.logger.Debug("unknown board")
-			return nil, ErrCannotIdentifyBoard
-		} else if err != nil {
-			r.logger.Debug("error getting board type", slog.Any("error", err))
-			return nil, err
-		} else {
-			r.logger.Debug("board type", slog.String("type", string(boardType.GetPrettyName())))
-			return boardType, nil
+		boardType, ramFromMemInfo := resolveJetsonRAM(r.logger, boardType)
+		boardType = resolveJetsonSuper(r.logger, boardType)
+		r.logger.Debug("board type", slog.String("source", source.Name), slog.String("type", string(boardType.GetPrettyName())), slog.Bool("ramFromMemInfo", ramFromMemInfo))
+		if ramFromMemInfo {
+			return JetsonBoardType{SBC: boardType, RAMFromMemInfo: true}, nil
 		}
-	} else if err != nil {
-		r.logger.Debug("error getting board type", slog.Any("error", err))
-		return nil, err
-	} else {
-		r.logger.Debug("board type", slog.String("type", string(boardType.GetPrettyName())))
 		return boardType, nil
 	}
+	r.logger.Debug("unknown board")
+	return nil, ErrCannotIdentifyBoard
 }
 
 func getBoardTypeFromModuleModel(logger *slog.Logger) (boardtype.SBC, error) {
@@ -163,7 +166,7 @@ func getBoardTypeFromModuleModel(logger *slog.Logger) (boardtype.SBC, error) {
 		return nil, err
 	}
 	for _, m := range jetsonModulesByModelNumber {
-		if strings.Contains(moduleModel, m.Model) {
+		if moduleModel == m.Model {
 			return m.Type, nil
 		}
 	}
@@ -207,9 +210,8 @@ func getModuleNameFromDtsFilename(logger *slog.Logger, dtsFilename string) (stri
 }
 
 func getModuleModelFromModuleName(logger *slog.Logger, moduleName string) (string, error) {
-	parts := strings.Split(moduleName, "-")
-	if len(parts) >= 4 {
-		ret := strings.Join(parts[1:3], "-")
+	ret, _, _ := parseModuleName(logger, moduleName)
+	if ret != "" {
 		logger.Debug("module model", slog.String("model", ret))
 		return ret, nil
 	}
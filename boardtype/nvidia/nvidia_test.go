@@ -1,16 +1,22 @@
 package nvidia
 
 import (
+	"context"
+	"encoding/binary"
 	"fmt"
 	"log/slog"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
 )
 
 func TestParseModuleName(t *testing.T) {
@@ -21,9 +27,14 @@ func TestParseModuleName(t *testing.T) {
 	var detectedType boardtype.SBC
 	f, e = getModuleNameFromDtsFilename(logger, "/nv-public/nv-platform/tegra234-p3768-0000+p3767-0000-nv-dsboard-ornx.dts")
 	require.NoError(t, e)
-	require.Equal(t, "tegra234-p3768-0000+p3767-0000-nv-board-orin", f)
+	require.Equal(t, "tegra234-p3768-0000+p3767-0000-nv-dsboard-ornx", f)
+	module, carrier, _ := parseModuleName(logger, f)
+	require.Equal(t, "p3767-0000", module)
+	require.Equal(t, "p3768-0000", carrier)
+	c := parseJetsonCompatible(logger, []string{"nvidia,p3768-0000+p3767-0000", "nvidia,tegra234"})
+	require.Equal(t, jetsonCompatible{Module: module, Carrier: carrier, SoC: "tegra234"}, c)
 	for _, m := range jetsonModulesByModelNumber {
-		if strings.Contains(f, m.Model) {
+		if m.Model == c.Module {
 			detectedType = m.Type
 		}
 	}
@@ -58,3 +69,517 @@ func TestIsBoardType(t *testing.T) {
 		})
 	}
 }
+
+func buildModuleEEPROM(partNumber string, serialNumber string) []byte {
+	data := make([]byte, moduleEEPROMSize)
+	binary.LittleEndian.PutUint16(data[0:2], 2)
+	binary.LittleEndian.PutUint16(data[4:6], 3767)
+	binary.LittleEndian.PutUint16(data[6:8], 5)
+	copy(data[moduleEEPROMPartNumber:], partNumber)
+	copy(data[moduleEEPROMEthernetMAC:], []byte{0x56, 0x34, 0x12, 0x0f, 0x4b, 0x48})
+	copy(data[moduleEEPROMSerialNumber:], serialNumber)
+	data[moduleEEPROMCRC] = eepromCRC8(data[:moduleEEPROMCRC])
+	return data
+}
+
+func TestParseModuleEEPROM(t *testing.T) {
+	eeprom, err := ParseModuleEEPROM(buildModuleEEPROM("699-13767-0005-300 K.2", "1422922012345"))
+	require.NoError(t, err)
+	assert.Equal(t, "699-13767-0005-300", eeprom.PartNumber)
+	assert.Equal(t, "K.2", eeprom.Revision)
+	assert.Equal(t, "1422922012345", eeprom.SerialNumber)
+	assert.Equal(t, uint16(3767), eeprom.BoardID)
+	assert.Equal(t, uint16(5), eeprom.SKU)
+	assert.Equal(t, "48:4b:0f:12:34:56", eeprom.EthernetMAC.String())
+	assert.Nil(t, eeprom.WiFiMAC)
+	model, err := eeprom.ModuleModel()
+	require.NoError(t, err)
+	assert.Equal(t, "p3767-0005", model)
+
+	eeprom, err = ParseModuleEEPROM(buildModuleEEPROM("900-13701-0005-000", ""))
+	require.NoError(t, err)
+	assert.Equal(t, "", eeprom.Revision)
+	model, err = eeprom.ModuleModel()
+	require.NoError(t, err)
+	assert.Equal(t, "p3701-0005", model)
+
+	data := buildModuleEEPROM("699-13768-0000-500 A.0", "")
+	for i := moduleEEPROMSerialNumber; i < moduleEEPROMSerialEnd; i++ {
+		data[i] = 0xff
+	}
+	data[moduleEEPROMCRC] = eepromCRC8(data[:moduleEEPROMCRC])
+	eeprom, err = ParseModuleEEPROM(data)
+	require.NoError(t, err)
+	assert.Equal(t, "", eeprom.SerialNumber)
+
+	eeprom, err = ParseModuleEEPROM(buildModuleEEPROM("699-13767-0005-300 K.2", "1422922012345678"))
+	require.NoError(t, err)
+	assert.Equal(t, "1422922012345678", eeprom.SerialNumber)
+
+	data = buildModuleEEPROM("699-13767-0005-300 K.2", "1422922012345")
+	data[moduleEEPROMCRC] ^= 0xff
+	_, err = ParseModuleEEPROM(data)
+	assert.Equal(t, ErrModuleEEPROMCRC, err)
+	assert.Equal(t, uint8(0xa1), eepromCRC8([]byte("123456789")))
+
+	_, err = ParseModuleEEPROM(buildModuleEEPROM("", ""))
+	assert.Equal(t, ErrInvalidModuleEEPROM, err)
+	_, err = ParseModuleEEPROM(make([]byte, 16))
+	assert.Equal(t, ErrInvalidModuleEEPROM, err)
+}
+
+func TestParseModuleNameIntoModuleAndCarrier(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	tests := []struct {
+		name     string
+		module   string
+		carrier  string
+		revision string
+	}{
+		{"tegra234-p3767-0003-p3768-0000-a0", "p3767-0003", "p3768-0000", "a0"},
+		{"tegra234-p3701-0005-p3737-0000", "p3701-0005", "p3737-0000", ""},
+		{"tegra210-p3448-0000-p3449-0000-b00", "p3448-0000", "p3449-0000", "b00"},
+		{"tegra194-p3668-all-p3509-0000", "p3668-all", "p3509-0000", ""},
+		{"tegra186-quill-p3310-1000-c03-00-base", "p3310-1000", "", ""},
+		{"tegra234-p3768-0000+p3767-0005-nv", "p3767-0005", "p3768-0000", ""},
+		{"tegra234-p3737-0000+p3701-0005", "p3701-0005", "p3737-0000", ""},
+		{"foo", "", "", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			module, carrier, revision := parseModuleName(logger, test.name)
+			assert.Equal(t, test.module, module)
+			assert.Equal(t, test.carrier, carrier)
+			assert.Equal(t, test.revision, revision)
+		})
+	}
+}
+
+func TestGetCarrierType(t *testing.T) {
+	seeedJ401 := boardtype.BoardType{Manufacturer: "Seeed", Model: "reComputer", SubModel: "J401 Carrier", BaseModel: &boardtype.JetsonCarrier}
+	RegisterCarrierBoard("reComputer J401", seeedJ401)
+	defer func() { registeredCarriers = registeredCarriers[:0] }()
+
+	assert.Equal(t, boardtype.JetsonOrinNanoCarrier, getCarrierType(JetsonPart{Model: "p3768-0000"}, "tegra234-p3767-0003-p3768-0000-a0", "NVIDIA Jetson Orin Nano Developer Kit"))
+	assert.Equal(t, seeedJ401, getCarrierType(JetsonPart{Model: "p3768-0000"}, "tegra234-p3767-0003-p3768-0000-a0", "NVIDIA reComputer J401"))
+	assert.Nil(t, getCarrierType(JetsonPart{Model: "p9999-0000"}, "", ""))
+	assert.True(t, seeedJ401.IsBoardType(boardtype.JetsonCarrier))
+	assert.True(t, boardtype.JetsonXavierNXCarrier.IsBoardType(boardtype.NVIDIA))
+	assert.False(t, boardtype.JetsonXavierNXCarrier.IsBoardType(boardtype.Jetson))
+	assert.Equal(t, boardtype.JetsonXavierNXCarrier, getCarrierType(JetsonPart{Model: "p3509-0000"}, "", ""))
+	for _, m := range jetsonModulesByModelNumber {
+		assert.False(t, m.Type.IsBoardType(boardtype.JetsonCarrier), m.Model)
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Version
+		err      error
+	}{
+		{"35.4.1", Version{35, 4, 1, 0}, nil},
+		{"R32.7.1", Version{32, 7, 1, 0}, nil},
+		{"35.4.1-20230801124926", Version{35, 4, 1, 0}, nil},
+		{"8.9.4.25-1+cuda12.2", Version{8, 9, 4, 25}, nil},
+		{"12", Version{12, 0, 0, 0}, nil},
+		{"", Version{}, ErrInvalidVersion},
+		{"foo", Version{}, ErrInvalidVersion},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			v, err := ParseVersion(test.input)
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.expected, v)
+		})
+	}
+	assert.True(t, Version{35, 4, 1, 0}.IsAtLeast(Version{35, 4, 1, 0}))
+	assert.True(t, Version{36, 0, 0, 0}.IsAtLeast(Version{35, 6, 1, 0}))
+	assert.False(t, Version{32, 7, 6, 0}.IsAtLeast(Version{35, 1, 0, 0}))
+	assert.Equal(t, "8.9.4.25", Version{8, 9, 4, 25}.String())
+}
+
+func TestReadL4TRelease(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "etc"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, nvTegraReleaseFile), []byte("# R35 (release), REVISION: 4.1, GCID: 33958178, BOARD: t186ref, EABI: aarch64, DATE: Tue Aug  1 19:57:35 UTC 2023\n# KERNEL_VARIANT: oot\n"), 0644))
+	release, err := readL4TRelease(logger, root)
+	require.NoError(t, err)
+	assert.Equal(t, Version{35, 4, 1, 0}, release.Version)
+	assert.Equal(t, "t186ref", release.Board)
+	assert.Equal(t, "33958178", release.GCID)
+	assert.Equal(t, "aarch64", release.EABI)
+	assert.Equal(t, time.Date(2023, 8, 1, 19, 57, 35, 0, time.UTC), release.Date.UTC())
+	assert.Equal(t, Version{5, 1, 2, 0}, release.JetPack)
+	assert.Equal(t, "5.1.2", release.JetPackName)
+
+	root = t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "var/lib/dpkg"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, dpkgStatusFile), []byte(`Package: nvidia-l4t-kernel
+Status: install ok installed
+Version: 5.15.136-tegra-36.4.0-20240912212859
+
+Package: nvidia-l4t-core
+Status: install ok installed
+Priority: standard
+Description: NVIDIA Core Package
+ multi-line description
+Version: 36.4.0-20240912212859
+
+Package: nvidia-l4t-removed
+Status: deinstall ok config-files
+Version: 1.0
+`), 0644))
+	release, err = readL4TRelease(logger, root)
+	require.NoError(t, err)
+	assert.Equal(t, Version{36, 4, 0, 0}, release.Version)
+	assert.Equal(t, "6.1", release.JetPackName)
+
+	packages, err := getInstalledPackages(logger, root)
+	require.NoError(t, err)
+	assert.Len(t, packages, 2)
+
+	_, err = readL4TRelease(logger, t.TempDir())
+	assert.Equal(t, ErrL4TNotFound, err)
+
+	_, err = parseNvTegraRelease(logger, "garbage")
+	assert.Equal(t, ErrInvalidL4TRelease, err)
+}
+
+func writeRootfs(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0644))
+	}
+	return root
+}
+
+func TestProbeSoftwareStack(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	root := writeRootfs(t, map[string]string{
+		"usr/local/cuda/version.json":                      `{"cuda": {"name": "CUDA SDK", "version": "12.2.140"}, "cuda_cudart": {"name": "CUDA Runtime (cudart)", "version": "12.2.140"}}`,
+		"usr/include/aarch64-linux-gnu/cudnn_version_v8.h": "#ifndef CUDNN_VERSION_H_\n#define CUDNN_MAJOR 8\n#define CUDNN_MINOR 9\n#define CUDNN_PATCHLEVEL 4\n",
+		"usr/include/aarch64-linux-gnu/NvInferVersion.h":   "#define TRT_MAJOR_ENTERPRISE 10\n#define TRT_MINOR_ENTERPRISE 3\n#define TRT_PATCH_ENTERPRISE 0\n#define TRT_BUILD_ENTERPRISE 30\n#define NV_TENSORRT_MAJOR TRT_MAJOR_ENTERPRISE //!< TensorRT major version.\n#define NV_TENSORRT_MINOR TRT_MINOR_ENTERPRISE\n#define NV_TENSORRT_PATCH TRT_PATCH_ENTERPRISE\n#define NV_TENSORRT_BUILD TRT_BUILD_ENTERPRISE\n",
+		"opt/nvidia/deepstream/deepstream/version":         "Version: 7.1\n",
+		"var/lib/dpkg/status":                              "Package: libnvvpi3\nStatus: install ok installed\nVersion: 3.2.4\n\nPackage: vpi3-dev\nStatus: install ok installed\nVersion: 3.2.4\n",
+	})
+	stack, err := ProbeSoftwareStack(logger, root)
+	require.NoError(t, err)
+	assert.Equal(t, Version{12, 2, 140, 0}, stack.CUDA)
+	assert.Equal(t, Version{8, 9, 4, 0}, stack.CuDNN)
+	assert.Equal(t, Version{10, 3, 0, 30}, stack.TensorRT)
+	assert.Equal(t, Version{3, 2, 4, 0}, stack.VPI)
+	assert.Equal(t, Version{7, 1, 0, 0}, stack.DeepStream)
+
+	root = writeRootfs(t, map[string]string{
+		"usr/local/cuda/version.txt": "CUDA Version 10.2.300\n",
+		"var/lib/dpkg/status": `Package: libcudnn8
+Status: install ok installed
+Version: 8.2.1.32-1+cuda10.2
+
+Package: libnvinfer8
+Status: install ok installed
+Version: 8.2.1-1+cuda10.2
+
+Package: libnvinfer-bin
+Status: install ok installed
+Version: 9.9.9-1+cuda10.2
+
+Package: deepstream-6.0
+Status: install ok installed
+Version: 6.0.1-1
+`,
+	})
+	stack, err = ProbeSoftwareStack(logger, root)
+	require.NoError(t, err)
+	assert.Equal(t, Version{10, 2, 300, 0}, stack.CUDA)
+	assert.Equal(t, Version{8, 2, 1, 32}, stack.CuDNN)
+	assert.Equal(t, Version{8, 2, 1, 0}, stack.TensorRT)
+	assert.True(t, stack.VPI.IsZero())
+	assert.Equal(t, Version{6, 0, 1, 0}, stack.DeepStream)
+
+	_, err = ProbeSoftwareStack(logger, filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+const testNvpmodelConf = `# nvpmodel configuration for Orin Nano
+< PARAM TYPE=FILE NAME=CPU_ONLINE >
+CORE_0 /sys/devices/system/cpu/cpu0/online
+CORE_1 /sys/devices/system/cpu/cpu1/online
+
+< PARAM TYPE=CLOCK NAME=GPU >
+FREQ_TABLE /sys/devices/17000000.ga10b/devfreq/17000000.ga10b/available_frequencies
+MAX_FREQ /sys/devices/17000000.ga10b/devfreq/17000000.ga10b/max_freq
+
+< POWER_MODEL ID=0 NAME=15W >
+CPU_ONLINE CORE_0 1
+CPU_ONLINE CORE_1 1
+CPU_ONLINE CORE_2 1
+CPU_ONLINE CORE_3 1
+CPU_ONLINE CORE_4 0
+CPU_ONLINE CORE_5 0
+CPU_A78_0 MIN_FREQ 729600
+CPU_A78_0 MAX_FREQ 1510400
+GPU_POWER_CONTROL_ENABLE GPU_PWR_CNTL_EN on
+GPU MIN_FREQ 0
+GPU MAX_FREQ 624750000
+EMC MAX_FREQ 2133000000
+
+< POWER_MODEL ID=1 NAME=7W >
+CPU_ONLINE CORE_0 1
+CPU_ONLINE CORE_1 1
+CPU_A78_0 MAX_FREQ 960000
+GPU MAX_FREQ 408000000
+EMC MAX_FREQ 2133000000
+
+< POWER_MODEL ID=2 NAME=MAXN_SUPER >
+CPU_ONLINE CORE_0 1
+CPU_ONLINE CORE_1 1
+CPU_ONLINE CORE_2 1
+CPU_ONLINE CORE_3 1
+CPU_ONLINE CORE_4 1
+CPU_ONLINE CORE_5 1
+CPU_A78_0 MAX_FREQ -1
+GPU MAX_FREQ -1
+EMC MAX_FREQ 0
+
+< PM_CONFIG DEFAULT=2 >
+`
+
+func TestReadNvpmodelConfig(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	root := writeRootfs(t, map[string]string{
+		nvpmodelConfFile:   testNvpmodelConf,
+		nvpmodelStatusFile: "pmode:0002 fmode:quiet\n",
+	})
+	config, err := readNvpmodelConfig(logger, root)
+	require.NoError(t, err)
+	assert.Equal(t, 2, config.DefaultMode)
+	require.Len(t, config.Modes, 3)
+
+	mode, err := config.GetMode(0)
+	require.NoError(t, err)
+	assert.Equal(t, "15W", mode.Name)
+	assert.Equal(t, uint64(0x0f), mode.CPUOnlineMask)
+	assert.Equal(t, int64(1510400), mode.CPUMaxFreq["CPU_A78_0"])
+	assert.Equal(t, int64(624750000), mode.GPUMaxFreq)
+	assert.Equal(t, int64(2133000000), mode.EMCMaxFreq)
+	assert.Len(t, mode.Settings, 12)
+	assert.False(t, mode.IsMaxN())
+
+	mode, err = config.GetMode(2)
+	require.NoError(t, err)
+	assert.True(t, mode.IsMaxN())
+	assert.True(t, mode.IsSuper())
+	assert.Equal(t, uint64(0x3f), mode.CPUOnlineMask)
+	assert.Equal(t, int64(-1), mode.GPUMaxFreq)
+
+	_, err = config.GetMode(7)
+	assert.Equal(t, ErrUnknownPowerMode, err)
+
+	status, err := readPowerModeStatus(logger, root)
+	require.NoError(t, err)
+	assert.Equal(t, "MAXN_SUPER", status.Mode.Name)
+	assert.Equal(t, "quiet", status.FanMode)
+	assert.False(t, status.JetsonClocks)
+
+	_, err = readNvpmodelConfig(logger, t.TempDir())
+	assert.Equal(t, ErrNvpmodelNotFound, err)
+
+	_, _, err = parseNvpmodelStatus("fmode:quiet")
+	assert.Equal(t, ErrInvalidNvpmodelStatus, err)
+}
+
+func TestReadJetsonClocksActive(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	files := map[string]string{
+		"sys/devices/system/cpu/cpu0/cpufreq/scaling_min_freq": "1510400\n",
+		"sys/devices/system/cpu/cpu0/cpufreq/scaling_max_freq": "1510400\n",
+		"sys/devices/system/cpu/cpu1/cpufreq/scaling_min_freq": "1510400\n",
+		"sys/devices/system/cpu/cpu1/cpufreq/scaling_max_freq": "1510400\n",
+		"sys/devices/system/cpu/cpu4/online":                   "0\n",
+		"sys/devices/system/cpu/cpu4/cpufreq/scaling_min_freq": "729600\n",
+		"sys/devices/system/cpu/cpu4/cpufreq/scaling_max_freq": "1510400\n",
+		"sys/class/devfreq/17000000.ga10b/min_freq":            "624750000\n",
+		"sys/class/devfreq/17000000.ga10b/max_freq":            "624750000\n",
+		"sys/class/devfreq/15380000.nvjpg/min_freq":            "0\n",
+		"sys/class/devfreq/15380000.nvjpg/max_freq":            "729600000\n",
+	}
+	active, err := readJetsonClocksActive(logger, writeRootfs(t, files))
+	require.NoError(t, err)
+	assert.True(t, active)
+
+	files["sys/class/devfreq/17000000.ga10b/min_freq"] = "306000000\n"
+	active, err = readJetsonClocksActive(logger, writeRootfs(t, files))
+	require.NoError(t, err)
+	assert.False(t, active)
+
+	_, err = readJetsonClocksActive(logger, t.TempDir())
+	assert.Equal(t, ErrCPUFreqNotAvailable, err)
+}
+
+func TestParseTegrastatsLine(t *testing.T) {
+	orin := "10-19-2024 10:00:01 RAM 2370/7620MB (lfb 1071x4MB) SWAP 0/3810MB (cached 0MB) CPU [2%@1510,1%@1510,0%@1510,0%@1510,off,off] EMC_FREQ 0%@2133 GR3D_FREQ 0%@[305] VIC_FREQ 729 APE 200 CV0@-256C CPU@48.5C SOC2@45.437C tj@48.5C VDD_IN 4047mW/4047mW VDD_CPU_GPU_CV 403mW/403mW NEW_FIELD 42"
+	sample, err := ParseTegrastatsLine(orin)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 10, 19, 10, 0, 1, 0, time.UTC), sample.Time)
+	assert.Equal(t, TegrastatsMemory{UsedMB: 2370, TotalMB: 7620, LargestFreeBlocks: 1071, LargestFreeBlockMB: 4}, sample.RAM)
+	assert.Equal(t, TegrastatsMemory{UsedMB: 0, TotalMB: 3810}, sample.Swap)
+	require.Len(t, sample.CPUs, 6)
+	assert.Equal(t, TegrastatsCPU{Online: true, Load: 2, FreqMHz: 1510}, sample.CPUs[0])
+	assert.False(t, sample.CPUs[5].Online)
+	assert.Equal(t, TegrastatsEngine{Load: 0, FreqMHz: []int{2133}}, sample.EMC)
+	assert.Equal(t, TegrastatsEngine{Load: 0, FreqMHz: []int{305}}, sample.GPU)
+	assert.Equal(t, 48.5, sample.Temperatures["CPU"])
+	assert.Equal(t, -256.0, sample.Temperatures["CV0"])
+	assert.Equal(t, 48.5, sample.Temperatures["tj"])
+	assert.Equal(t, TegrastatsPowerRail{CurrentMW: 4047, AverageMW: 4047}, sample.PowerRails["VDD_IN"])
+	assert.Equal(t, "729", sample.Fields["VIC_FREQ"])
+	assert.Equal(t, "42", sample.Fields["NEW_FIELD"])
+	assert.Empty(t, sample.Unparsed)
+
+	nano := "RAM 1608/3956MB (lfb 81x4MB) SWAP 0/1978MB (cached 0MB) IRAM 0/252kB(lfb 252kB) CPU [9%@102,2%@102,1%@102,0%@102] EMC_FREQ 0%@1600 GR3D_FREQ 12%@76 APE 25 PLL@33C CPU@35C PMIC@100C GPU@34C AO@40.5C thermal@34.5C POM_5V_IN 1250/1250 POM_5V_GPU 0/0 POM_5V_CPU 167/167"
+	sample, err = ParseTegrastatsLine(nano)
+	require.NoError(t, err)
+	assert.True(t, sample.Time.IsZero())
+	assert.Equal(t, 1608, sample.RAM.UsedMB)
+	assert.Len(t, sample.CPUs, 4)
+	assert.Equal(t, TegrastatsEngine{Load: 12, FreqMHz: []int{76}}, sample.GPU)
+	assert.Equal(t, TegrastatsPowerRail{CurrentMW: 167, AverageMW: 167}, sample.PowerRails["POM_5V_CPU"])
+	assert.Equal(t, "0/252kB(lfb 252kB)", sample.Fields["IRAM"])
+	assert.Equal(t, 40.5, sample.Temperatures["AO"])
+
+	_, err = ParseTegrastatsLine("")
+	assert.Equal(t, ErrInvalidTegrastatsLine, err)
+	_, err = ParseTegrastatsLine("tegrastats: command not found")
+	assert.Equal(t, ErrInvalidTegrastatsLine, err)
+}
+
+func TestReadTegrastats(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	log := "RAM 1608/3956MB SWAP 0/1978MB CPU [9%@102,2%@102]\ngarbage\nRAM 1610/3956MB SWAP 0/1978MB CPU [9%@102,2%@102]\n"
+
+	samples := make([]TegrastatsSample, 0)
+	for s := range ReadTegrastats(context.Background(), logger, strings.NewReader(log)) {
+		samples = append(samples, s)
+	}
+	require.Len(t, samples, 2)
+	assert.Equal(t, 1610, samples[1].RAM.UsedMB)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := ReadTegrastats(ctx, logger, strings.NewReader(log))
+	<-ch
+	cancel()
+	for range ch {
+	}
+
+	execLookPath = func(string) (string, error) { return "", exec.ErrNotFound }
+	defer func() { execLookPath = exec.LookPath }()
+	_, err := StartTegrastats(context.Background(), logger, time.Second)
+	assert.Equal(t, ErrTegrastatsNotFound, err)
+}
+
+func TestParseJetsonCompatible(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	tests := []struct {
+		compatible []string
+		expected   jetsonCompatible
+	}{
+		{[]string{"nvidia,p3768-0000+p3767-0005", "nvidia,p3767-0005", "nvidia,tegra234"}, jetsonCompatible{"p3767-0005", "p3768-0000", "tegra234"}},
+		{[]string{"nvidia,p3737-0000+p3701-0005", "nvidia,p3701-0005", "nvidia,tegra234"}, jetsonCompatible{"p3701-0005", "p3737-0000", "tegra234"}},
+		{[]string{"nvidia,p3449-0000-b00+p3448-0002-b00", "nvidia,jetson-nano", "nvidia,tegra210"}, jetsonCompatible{"p3448-0002", "p3449-0000", "tegra210"}},
+		{[]string{"nvidia,p2972-0000", "nvidia,tegra194"}, jetsonCompatible{"p2972-0000", "", "tegra194"}},
+		{[]string{"raspberrypi,5-model-b", "brcm,bcm2712"}, jetsonCompatible{}},
+	}
+	for _, test := range tests {
+		t.Run(test.compatible[0], func(t *testing.T) {
+			assert.Equal(t, test.expected, parseJetsonCompatible(logger, test.compatible))
+		})
+	}
+}
+
+func TestGetJetsonRAMVariants(t *testing.T) {
+	tests := []struct {
+		board    boardtype.SBC
+		memMB    int
+		expected boardtype.SBC
+		resolved bool
+	}{
+		{boardtype.JetsonAGXOrin, 30697, boardtype.JetsonAGXOrin32GB, true},
+		{boardtype.JetsonAGXOrin, 62841, boardtype.JetsonAGXOrin64GB, true},
+		{boardtype.JetsonAGXXavier, 15658, boardtype.JetsonAGXXavier16GB, true},
+		{boardtype.JetsonAGXXavier, 7765, boardtype.JetsonAGXXavier8GB, true},
+		{boardtype.JetsonXavierNX, 15388, boardtype.JetsonXavierNX16GB, true},
+		{boardtype.JetsonNanoDeveloperKit, 1980, boardtype.JetsonNanoDeveloperKit2GB, true},
+		{boardtype.JetsonNanoDeveloperKit, 3956, boardtype.JetsonNanoDeveloperKit4GB, true},
+		{boardtype.JetsonAGXOrin, 128000, nil, false},
+		{boardtype.JetsonOrinNX16GB, 15388, nil, false},
+		{boardtype.JetsonTX1, 3956, nil, false},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s %d", test.board.GetPrettyName(), test.memMB), func(t *testing.T) {
+			board, resolved := identifier.MatchRAMVariant(test.memMB, getJetsonRAMVariants(test.board))
+			assert.Equal(t, test.expected, board)
+			assert.Equal(t, test.resolved, resolved)
+		})
+	}
+}
+
+func TestJetsonBoardType(t *testing.T) {
+	var board boardtype.SBC = JetsonBoardType{SBC: boardtype.JetsonAGXOrin32GB, RAMFromMemInfo: true}
+	assert.True(t, board.IsBoardType(boardtype.JetsonAGXOrin))
+	assert.Equal(t, boardtype.JetsonAGXOrin32GB.GetPrettyName(), board.GetPrettyName())
+	assert.Equal(t, 32768, board.GetRAM())
+	b, ok := board.(JetsonBoardType)
+	require.True(t, ok)
+	assert.True(t, b.RAMFromMemInfo)
+}
+
+func TestIsJetsonSuper(t *testing.T) {
+	tests := []struct {
+		moduleName string
+		compatible []string
+		dtbm       string
+		expected   bool
+	}{
+		{"tegra234-p3768-0000+p3767-0005-nv-super", nil, "", true},
+		{"", nil, "NVIDIA Jetson Orin Nano Engineering Reference Developer Kit Super", true},
+		{"", []string{"nvidia,p3768-0000+p3767-0005-super", "nvidia,tegra234"}, "", true},
+		{"tegra234-p3768-0000+p3767-0005-nv", []string{"nvidia,p3768-0000+p3767-0005", "nvidia,tegra234"}, "NVIDIA Jetson Orin Nano Engineering Reference Developer Kit", false},
+	}
+	for _, test := range tests {
+		t.Run(test.moduleName+test.dtbm, func(t *testing.T) {
+			assert.Equal(t, test.expected, isJetsonSuper(test.moduleName, test.compatible, test.dtbm))
+		})
+	}
+}
+
+func TestJetsonThor(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	module, carrier, _ := parseModuleName(logger, "tegra264-p3971-0000+p3834-0008-nv")
+	assert.Equal(t, "p3834-0008", module)
+	assert.Equal(t, "p3971-0000", carrier)
+	assert.Equal(t, boardtype.JetsonAGXThorCarrier, getCarrierType(JetsonPart{Model: carrier}, "", ""))
+
+	c := parseJetsonCompatible(logger, []string{"nvidia,p3971-0000+p3834-0008", "nvidia,tegra264"})
+	assert.Equal(t, jetsonCompatible{"p3834-0008", "p3971-0000", "tegra264"}, c)
+
+	modules := make(map[string]boardtype.SBC)
+	for _, m := range jetsonModulesByModelNumber {
+		modules[m.Model] = m.Type
+	}
+	assert.Equal(t, boardtype.JetsonAGXThorT4000, modules["p3834-0004"])
+	assert.Equal(t, boardtype.JetsonAGXThorT5000, modules["p3834-0008"])
+	c = parseJetsonCompatible(logger, []string{"nvidia,p3971-0000+p3834-0004", "nvidia,tegra264"})
+	assert.Equal(t, boardtype.JetsonAGXThorT4000, modules[c.Module])
+
+	board, resolved := identifier.MatchRAMVariant(125772, getJetsonRAMVariants(boardtype.JetsonAGXThor))
+	assert.True(t, resolved)
+	assert.Equal(t, boardtype.JetsonAGXThorT5000, board)
+	assert.True(t, boardtype.JetsonAGXThorDeveloperKit.IsBoardType(boardtype.JetsonThor))
+	assert.True(t, boardtype.JetsonOrinNanoSuperDeveloperKit.IsBoardType(boardtype.JetsonOrinNanoDeveloperKit))
+}
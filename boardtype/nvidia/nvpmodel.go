@@ -0,0 +1,292 @@
+package nvidia
+
+import (
+	"bufio"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	nvpmodelConfFile   = "etc/nvpmodel.conf"
+	nvpmodelStatusFile = "var/lib/nvpmodel/status"
+	cpuSysfsDir        = "sys/devices/system/cpu"
+	devfreqSysfsDir    = "sys/class/devfreq"
+)
+
+var (
+	ErrNvpmodelNotFound      = errors.New("nvpmodel configuration not found")
+	ErrInvalidNvpmodelConfig = errors.New("invalid nvpmodel configuration")
+	ErrInvalidNvpmodelStatus = errors.New("invalid nvpmodel status")
+	ErrUnknownPowerMode      = errors.New("unknown power mode")
+	ErrCPUFreqNotAvailable   = errors.New("cpufreq not available")
+	gpuDevfreqNames          = []string{"gpu", "gv11b", "ga10b", "gp10b", "gb10b"}
+)
+
+type PowerModeSetting struct {
+	Param string
+	Key   string
+	Value string
+}
+
+// PowerMode is a POWER_MODEL from nvpmodel.conf, frequencies are as written in the file so
+// -1 means the maximum and 0 usually means the minimum or no limit.
+type PowerMode struct {
+	ID            int
+	Name          string
+	CPUOnlineMask uint64
+	CPUMaxFreq    map[string]int64
+	GPUMinFreq    int64
+	GPUMaxFreq    int64
+	EMCMaxFreq    int64
+	Settings      []PowerModeSetting
+}
+
+func (m PowerMode) IsMaxN() bool {
+	return strings.HasPrefix(strings.ToUpper(m.Name), "MAXN")
+}
+
+func (m PowerMode) IsSuper() bool {
+	return strings.Contains(strings.ToUpper(m.Name), "SUPER")
+}
+
+type NvpmodelConfig struct {
+	DefaultMode int
+	Modes       []PowerMode
+}
+
+func (c NvpmodelConfig) GetMode(id int) (PowerMode, error) {
+	for _, m := range c.Modes {
+		if m.ID == id {
+			return m, nil
+		}
+	}
+	return PowerMode{}, ErrUnknownPowerMode
+}
+
+type PowerModeStatus struct {
+	Mode         PowerMode
+	FanMode      string
+	JetsonClocks bool
+}
+
+func GetNvpmodelConfig(logger *slog.Logger) (*NvpmodelConfig, error) {
+	return readNvpmodelConfig(logger, "/")
+}
+
+func GetPowerModeStatus(logger *slog.Logger) (*PowerModeStatus, error) {
+	return readPowerModeStatus(logger, "/")
+}
+
+func IsJetsonClocksActive(logger *slog.Logger) (bool, error) {
+	return readJetsonClocksActive(logger, "/")
+}
+
+func readPowerModeStatus(logger *slog.Logger, root string) (*PowerModeStatus, error) {
+	config, err := readNvpmodelConfig(logger, root)
+	if err != nil {
+		return nil, err
+	}
+	c, err := os.ReadFile(filepath.Join(root, nvpmodelStatusFile))
+	if err != nil {
+		logger.Debug("cannot read nvpmodel status", slog.Any("error", err))
+		return nil, err
+	}
+	id, fanMode, err := parseNvpmodelStatus(string(c))
+	if err != nil {
+		logger.Debug("cannot parse nvpmodel status", slog.String("status", string(c)))
+		return nil, err
+	}
+	mode, err := config.GetMode(id)
+	if err != nil {
+		logger.Debug("nvpmodel status refers to an unknown mode", slog.Int("id", id))
+		return nil, err
+	}
+	status := &PowerModeStatus{Mode: mode, FanMode: fanMode}
+	if status.JetsonClocks, err = readJetsonClocksActive(logger, root); err != nil {
+		logger.Debug("cannot determine jetson_clocks state", slog.Any("error", err))
+	}
+	logger.Debug("power mode", slog.Int("id", mode.ID), slog.String("name", mode.Name), slog.Bool("jetsonClocks", status.JetsonClocks))
+	return status, nil
+}
+
+// parseNvpmodelStatus parses the status file, which looks like pmode:0002 fmode:quiet
+func parseNvpmodelStatus(status string) (int, string, error) {
+	id := -1
+	var fanMode string
+	for _, field := range strings.Fields(status) {
+		key, value, _ := strings.Cut(field, ":")
+		switch key {
+		case "pmode":
+			v, err := strconv.Atoi(value)
+			if err != nil {
+				return 0, "", ErrInvalidNvpmodelStatus
+			}
+			id = v
+		case "fmode":
+			fanMode = value
+		}
+	}
+	if id < 0 {
+		return 0, "", ErrInvalidNvpmodelStatus
+	}
+	return id, fanMode, nil
+}
+
+func readNvpmodelConfig(logger *slog.Logger, root string) (*NvpmodelConfig, error) {
+	f, err := os.Open(filepath.Join(root, nvpmodelConfFile))
+	if err != nil {
+		logger.Debug("cannot open nvpmodel.conf", slog.Any("error", err))
+		return nil, ErrNvpmodelNotFound
+	}
+	defer f.Close()
+
+	config := &NvpmodelConfig{Modes: make([]PowerMode, 0)}
+	var mode *PowerMode
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "<") {
+			if mode != nil {
+				config.Modes = append(config.Modes, *mode)
+				mode = nil
+			}
+			fields := strings.Fields(strings.Trim(line, "<> "))
+			if len(fields) == 0 {
+				continue
+			}
+			attrs := parseNvpmodelAttributes(fields[1:])
+			switch fields[0] {
+			case "POWER_MODEL":
+				id, err := strconv.Atoi(attrs["ID"])
+				if err != nil {
+					logger.Debug("invalid power model", slog.String("line", line))
+					return nil, ErrInvalidNvpmodelConfig
+				}
+				mode = &PowerMode{ID: id, Name: attrs["NAME"], CPUMaxFreq: make(map[string]int64), Settings: make([]PowerModeSetting, 0)}
+			case "PM_CONFIG":
+				if v, err := strconv.Atoi(attrs["DEFAULT"]); err == nil {
+					config.DefaultMode = v
+				}
+			}
+			continue
+		}
+		if mode == nil {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		setting := PowerModeSetting{Param: fields[0], Key: fields[1], Value: fields[2]}
+		mode.Settings = append(mode.Settings, setting)
+		applyPowerModeSetting(mode, setting)
+	}
+	if mode != nil {
+		config.Modes = append(config.Modes, *mode)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(config.Modes) == 0 {
+		return nil, ErrInvalidNvpmodelConfig
+	}
+	return config, nil
+}
+
+func parseNvpmodelAttributes(fields []string) map[string]string {
+	attrs := make(map[string]string)
+	for _, f := range fields {
+		if key, value, ok := strings.Cut(f, "="); ok {
+			attrs[key] = value
+		}
+	}
+	return attrs
+}
+
+func applyPowerModeSetting(mode *PowerMode, setting PowerModeSetting) {
+	value, err := strconv.ParseInt(setting.Value, 10, 64)
+	if err != nil {
+		return
+	}
+	switch {
+	case setting.Param == "CPU_ONLINE":
+		core, err := strconv.Atoi(strings.TrimPrefix(setting.Key, "CORE_"))
+		if err == nil && core < 64 && value != 0 {
+			mode.CPUOnlineMask |= 1 << core
+		}
+	case strings.HasPrefix(setting.Param, "CPU_") && setting.Key == "MAX_FREQ":
+		mode.CPUMaxFreq[setting.Param] = value
+	case setting.Param == "GPU" && setting.Key == "MIN_FREQ":
+		mode.GPUMinFreq = value
+	case setting.Param == "GPU" && setting.Key == "MAX_FREQ":
+		mode.GPUMaxFreq = value
+	case setting.Param == "EMC" && setting.Key == "MAX_FREQ":
+		mode.EMCMaxFreq = value
+	}
+}
+
+// readJetsonClocksActive reports whether jetson_clocks has pinned the frequencies, it does that
+// by setting the minimum frequency of every online CPU and the GPU to the maximum.
+func readJetsonClocksActive(logger *slog.Logger, root string) (bool, error) {
+	cpus, err := filepath.Glob(filepath.Join(root, cpuSysfsDir, "cpu[0-9]*", "cpufreq"))
+	if err != nil || len(cpus) == 0 {
+		return false, ErrCPUFreqNotAvailable
+	}
+	for _, cpu := range cpus {
+		if online, err := os.ReadFile(filepath.Join(filepath.Dir(cpu), "online")); err == nil && strings.TrimSpace(string(online)) == "0" {
+			continue
+		}
+		pinned, err := isFrequencyPinned(filepath.Join(cpu, "scaling_min_freq"), filepath.Join(cpu, "scaling_max_freq"))
+		if err != nil {
+			return false, err
+		}
+		if !pinned {
+			logger.Debug("CPU frequency is not pinned", slog.String("cpu", filepath.Base(filepath.Dir(cpu))))
+			return false, nil
+		}
+	}
+	devices, _ := os.ReadDir(filepath.Join(root, devfreqSysfsDir))
+	for _, d := range devices {
+		if !isGPUDevfreq(d.Name()) {
+			continue
+		}
+		dir := filepath.Join(root, devfreqSysfsDir, d.Name())
+		pinned, err := isFrequencyPinned(filepath.Join(dir, "min_freq"), filepath.Join(dir, "max_freq"))
+		if err != nil {
+			return false, err
+		}
+		if !pinned {
+			logger.Debug("GPU frequency is not pinned", slog.String("device", d.Name()))
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func isGPUDevfreq(name string) bool {
+	for _, n := range gpuDevfreqNames {
+		if strings.HasSuffix(name, "."+n) || name == n {
+			return true
+		}
+	}
+	return false
+}
+
+func isFrequencyPinned(minFile string, maxFile string) (bool, error) {
+	minFreq, err := os.ReadFile(minFile)
+	if err != nil {
+		return false, err
+	}
+	maxFreq, err := os.ReadFile(maxFile)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(minFreq)) == strings.TrimSpace(string(maxFreq)), nil
+}
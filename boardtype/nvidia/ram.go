@@ -0,0 +1,49 @@
+package nvidia
+
+import (
+	"log/slog"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+type jetsonRAMVariants struct {
+	Type     boardtype.SBC
+	Variants []boardtype.SBC
+}
+
+// jetsonRAMVariantsByType lists the types that can't be told apart without looking at the
+// installed memory, either because the module number is shared or only the carrier is known.
+var jetsonRAMVariantsByType = []jetsonRAMVariants{
+	{boardtype.JetsonAGXThor, []boardtype.SBC{boardtype.JetsonAGXThorT4000, boardtype.JetsonAGXThorT5000}},
+	{boardtype.JetsonAGXOrin, []boardtype.SBC{boardtype.JetsonAGXOrin32GB, boardtype.JetsonAGXOrin64GB}},
+	{boardtype.JetsonAGXXavier, []boardtype.SBC{boardtype.JetsonAGXXavier8GB, boardtype.JetsonAGXXavier16GB, boardtype.JetsonAGXXavier32GB, boardtype.JetsonAGXXavier64GB}},
+	{boardtype.JetsonXavierNX, []boardtype.SBC{boardtype.JetsonXavierNX8GB, boardtype.JetsonXavierNX16GB}},
+	{boardtype.JetsonNano, []boardtype.SBC{boardtype.JetsonNano2GB, boardtype.JetsonNano4GB}},
+	{boardtype.JetsonNanoDeveloperKit, []boardtype.SBC{boardtype.JetsonNanoDeveloperKit2GB, boardtype.JetsonNanoDeveloperKit4GB}},
+}
+
+// JetsonBoardType is returned by GetBoardType when the RAM variant was worked out from MemTotal,
+// the embedded SBC is the resolved variant.
+type JetsonBoardType struct {
+	boardtype.SBC
+	RAMFromMemInfo bool
+}
+
+// resolveJetsonRAM returns the RAM variant of board when board has no RAM of its own, the bool
+// is true when the result came from meminfo rather than the board type.
+func resolveJetsonRAM(logger *slog.Logger, board boardtype.SBC) (boardtype.SBC, bool) {
+	if board.GetRAM() != 0 {
+		return board, false
+	}
+	return identifier.ResolveRAMVariant(logger, board, getJetsonRAMVariants(board))
+}
+
+func getJetsonRAMVariants(board boardtype.SBC) []boardtype.SBC {
+	for _, v := range jetsonRAMVariantsByType {
+		if v.Type == board {
+			return v.Variants
+		}
+	}
+	return nil
+}
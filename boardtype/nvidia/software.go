@@ -0,0 +1,184 @@
+package nvidia
+
+import (
+	"bufio"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	cudaVersionJSONFiles = []string{"usr/local/cuda/version.json"}
+	cudaVersionTextFiles = []string{"usr/local/cuda/version.txt"}
+	cudnnHeaderFiles     = []string{
+		"usr/include/cudnn_version.h",
+		"usr/include/aarch64-linux-gnu/cudnn_version_v9.h",
+		"usr/include/aarch64-linux-gnu/cudnn_version_v8.h",
+		"usr/include/aarch64-linux-gnu/cudnn_version.h",
+	}
+	tensorRTHeaderFiles = []string{
+		"usr/include/aarch64-linux-gnu/NvInferVersion.h",
+		"usr/include/NvInferVersion.h",
+	}
+	deepStreamVersionFiles = []string{"opt/nvidia/deepstream/deepstream/version"}
+)
+
+// SoftwareStack lists the versions of the NVIDIA libraries installed on a root filesystem,
+// a zero Version means the component wasn't found.
+type SoftwareStack struct {
+	CUDA       Version
+	CuDNN      Version
+	TensorRT   Version
+	VPI        Version
+	DeepStream Version
+}
+
+func GetSoftwareStack(logger *slog.Logger) (*SoftwareStack, error) {
+	return ProbeSoftwareStack(logger, "/")
+}
+
+// ProbeSoftwareStack only reads files under root, so it works on a captured rootfs as well as
+// the running system.
+func ProbeSoftwareStack(logger *slog.Logger, root string) (*SoftwareStack, error) {
+	if _, err := os.Stat(root); err != nil {
+		return nil, err
+	}
+	packages, err := getInstalledPackages(logger, root)
+	if err != nil {
+		packages = make(map[string]string)
+	}
+	stack := &SoftwareStack{
+		CUDA:       getCUDAVersion(logger, root, packages),
+		CuDNN:      getHeaderVersion(logger, root, cudnnHeaderFiles, "CUDNN_MAJOR", "CUDNN_MINOR", "CUDNN_PATCHLEVEL", ""),
+		TensorRT:   getHeaderVersion(logger, root, tensorRTHeaderFiles, "NV_TENSORRT_MAJOR", "NV_TENSORRT_MINOR", "NV_TENSORRT_PATCH", "NV_TENSORRT_BUILD"),
+		DeepStream: getDeepStreamVersion(logger, root, packages),
+		VPI:        getPackageVersion(packages, func(name string) bool { return isVersionedPackage(name, "libnvvpi") }),
+	}
+	if stack.CuDNN.IsZero() {
+		stack.CuDNN = getPackageVersion(packages, func(name string) bool { return strings.HasPrefix(name, "libcudnn") })
+	}
+	if stack.TensorRT.IsZero() {
+		stack.TensorRT = getPackageVersion(packages, func(name string) bool { return name == "tensorrt" || isVersionedPackage(name, "libnvinfer") })
+	}
+	logger.Debug("software stack", slog.String("cuda", stack.CUDA.String()), slog.String("cudnn", stack.CuDNN.String()), slog.String("tensorrt", stack.TensorRT.String()), slog.String("vpi", stack.VPI.String()), slog.String("deepstream", stack.DeepStream.String()))
+	return stack, nil
+}
+
+func getCUDAVersion(logger *slog.Logger, root string, packages map[string]string) Version {
+	for _, f := range cudaVersionJSONFiles {
+		c, err := os.ReadFile(filepath.Join(root, f))
+		if err != nil {
+			continue
+		}
+		var versions map[string]struct {
+			Version string `json:"version"`
+		}
+		if err := json.Unmarshal(c, &versions); err != nil {
+			logger.Debug("cannot parse CUDA version.json", slog.Any("error", err))
+			continue
+		}
+		if v, err := ParseVersion(versions["cuda"].Version); err == nil {
+			return v
+		}
+	}
+	for _, f := range cudaVersionTextFiles {
+		c, err := os.ReadFile(filepath.Join(root, f))
+		if err != nil {
+			continue
+		}
+		if v, err := ParseVersion(strings.TrimPrefix(strings.TrimSpace(string(c)), "CUDA Version ")); err == nil {
+			return v
+		}
+	}
+	return getPackageVersion(packages, func(name string) bool {
+		return strings.HasPrefix(name, "cuda-cudart-") && !strings.HasPrefix(name, "cuda-cudart-dev-")
+	})
+}
+
+func getDeepStreamVersion(logger *slog.Logger, root string, packages map[string]string) Version {
+	for _, f := range deepStreamVersionFiles {
+		c, err := os.ReadFile(filepath.Join(root, f))
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(c), "\n") {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(line), "Version:"); ok {
+				if v, err := ParseVersion(value); err == nil {
+					return v
+				}
+			}
+		}
+		logger.Debug("cannot parse DeepStream version file", slog.String("path", f))
+	}
+	return getPackageVersion(packages, func(name string) bool { return strings.HasPrefix(name, "deepstream-") })
+}
+
+// getHeaderVersion reads the version #defines from the first header found, defines that refer
+// to another define (as TensorRT 10 does) are followed.
+func getHeaderVersion(logger *slog.Logger, root string, headers []string, major, minor, patch, build string) Version {
+	for _, h := range headers {
+		f, err := os.Open(filepath.Join(root, h))
+		if err != nil {
+			continue
+		}
+		defines := make(map[string]string)
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) >= 3 && fields[0] == "#define" {
+				defines[fields[1]] = fields[2]
+			}
+		}
+		f.Close()
+		resolve := func(name string) string {
+			value := defines[name]
+			for i := 0; i < 4; i++ {
+				next, ok := defines[value]
+				if !ok {
+					break
+				}
+				value = next
+			}
+			return value
+		}
+		s := resolve(major) + "." + resolve(minor) + "." + resolve(patch)
+		if build != "" && resolve(build) != "" {
+			s += "." + resolve(build)
+		}
+		if v, err := ParseVersion(s); err == nil {
+			return v
+		}
+		logger.Debug("cannot parse version header", slog.String("path", h), slog.String("version", s))
+	}
+	return Version{}
+}
+
+// getPackageVersion returns the highest version of the installed packages matching match.
+func getPackageVersion(packages map[string]string, match func(string) bool) Version {
+	var best Version
+	for name, version := range packages {
+		if !match(name) {
+			continue
+		}
+		if v, err := ParseVersion(version); err == nil && v.Compare(best) > 0 {
+			best = v
+		}
+	}
+	return best
+}
+
+// isVersionedPackage matches names like libnvinfer8 or libnvinfer10 but not libnvinfer-bin.
+func isVersionedPackage(name string, prefix string) bool {
+	rest, ok := strings.CutPrefix(name, prefix)
+	if !ok || rest == "" {
+		return false
+	}
+	for _, c := range rest {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
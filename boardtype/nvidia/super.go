@@ -0,0 +1,57 @@
+package nvidia
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+type jetsonSuperVariant struct {
+	Type  boardtype.SBC
+	Super boardtype.SBC
+}
+
+// Super mode is enabled by flashing the -super device tree, the module itself is unchanged.
+var jetsonSuperVariants = []jetsonSuperVariant{
+	{boardtype.JetsonOrinNanoDeveloperKit, boardtype.JetsonOrinNanoSuperDeveloperKit},
+	{boardtype.JetsonOrinNano8GB, boardtype.JetsonOrinNano8GBSuper},
+	{boardtype.JetsonOrinNano4GB, boardtype.JetsonOrinNano4GBSuper},
+	{boardtype.JetsonOrinNX16GB, boardtype.JetsonOrinNX16GBSuper},
+	{boardtype.JetsonOrinNX8GB, boardtype.JetsonOrinNX8GBSuper},
+}
+
+func resolveJetsonSuper(logger *slog.Logger, board boardtype.SBC) boardtype.SBC {
+	for _, v := range jetsonSuperVariants {
+		if v.Type != board {
+			continue
+		}
+		var moduleName string
+		if dtsFilename, err := getDtsFile(logger); err == nil {
+			moduleName, _ = getModuleNameFromDtsFilename(logger, dtsFilename)
+		}
+		compatible, _ := identifier.GetDeviceTreeCompatible(logger)
+		dtbm, _ := identifier.GetDeviceTreeBaseModel(logger)
+		if isJetsonSuper(moduleName, compatible, dtbm) {
+			logger.Debug("super device tree in use", slog.String("type", v.Super.GetPrettyName()))
+			return v.Super
+		}
+		return board
+	}
+	return board
+}
+
+// isJetsonSuper matches the device tree the Super devkits are flashed with, the DTS is named
+// tegra234-p3768-0000+p3767-0005-nv-super and the model ends in Super.
+func isJetsonSuper(moduleName string, compatible []string, dtbm string) bool {
+	if strings.HasSuffix(moduleName, "-super") || strings.HasSuffix(dtbm, " Super") {
+		return true
+	}
+	for _, c := range compatible {
+		if strings.HasPrefix(c, "nvidia,") && strings.HasSuffix(c, "-super") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,331 @@
+package nvidia
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tegrastatsTimeLayout = "01-02-2006 15:04:05"
+)
+
+var (
+	ErrTegrastatsNotFound     = errors.New("tegrastats not found")
+	ErrInvalidTegrastatsLine  = errors.New("invalid tegrastats line")
+	execLookPath              = exec.LookPath
+	tegrastatsMemoryUnitsInMB = map[string]float64{"kB": 1.0 / 1024, "MB": 1, "GB": 1024}
+)
+
+type TegrastatsMemory struct {
+	UsedMB             int
+	TotalMB            int
+	CachedMB           int
+	LargestFreeBlocks  int
+	LargestFreeBlockMB int
+}
+
+type TegrastatsCPU struct {
+	Online  bool
+	Load    int
+	FreqMHz int
+}
+
+// TegrastatsEngine is a load percentage and clock, Orin reports one GPU clock per GPC so
+// FreqMHz can hold more than one value.
+type TegrastatsEngine struct {
+	Load    int
+	FreqMHz []int
+}
+
+type TegrastatsPowerRail struct {
+	CurrentMW int
+	AverageMW int
+}
+
+// TegrastatsSample is one line of tegrastats output, fields that aren't understood are kept in
+// Fields (name and value pairs) and Unparsed (anything else) so newer formats aren't lost.
+type TegrastatsSample struct {
+	Time         time.Time
+	RAM          TegrastatsMemory
+	Swap         TegrastatsMemory
+	CPUs         []TegrastatsCPU
+	EMC          TegrastatsEngine
+	GPU          TegrastatsEngine
+	Temperatures map[string]float64
+	PowerRails   map[string]TegrastatsPowerRail
+	Fields       map[string]string
+	Unparsed     []string
+	Raw          string
+}
+
+// StartTegrastats runs tegrastats and sends a sample for every line it prints, the channel is
+// closed when ctx is cancelled or tegrastats exits.
+func StartTegrastats(ctx context.Context, logger *slog.Logger, interval time.Duration) (<-chan TegrastatsSample, error) {
+	path, err := execLookPath("tegrastats")
+	if err != nil {
+		logger.Debug("tegrastats not found", slog.Any("error", err))
+		return nil, ErrTegrastatsNotFound
+	}
+	cmd := exec.CommandContext(ctx, path, "--interval", strconv.FormatInt(interval.Milliseconds(), 10))
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	samples := make(chan TegrastatsSample)
+	go func() {
+		defer close(samples)
+		readTegrastats(ctx, logger, stdout, samples)
+		if err := cmd.Wait(); err != nil && ctx.Err() == nil {
+			logger.Debug("tegrastats exited", slog.Any("error", err))
+		}
+	}()
+	return samples, nil
+}
+
+// ReadTegrastats parses a recorded tegrastats log, lines that can't be parsed are skipped.
+func ReadTegrastats(ctx context.Context, logger *slog.Logger, r io.Reader) <-chan TegrastatsSample {
+	samples := make(chan TegrastatsSample)
+	go func() {
+		defer close(samples)
+		readTegrastats(ctx, logger, r, samples)
+	}()
+	return samples
+}
+
+func readTegrastats(ctx context.Context, logger *slog.Logger, r io.Reader, samples chan<- TegrastatsSample) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		sample, err := ParseTegrastatsLine(scanner.Text())
+		if err != nil {
+			logger.Debug("skipping tegrastats line", slog.String("line", scanner.Text()), slog.Any("error", err))
+			continue
+		}
+		select {
+		case samples <- sample:
+		case <-ctx.Done():
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		logger.Debug("error reading tegrastats", slog.Any("error", err))
+	}
+}
+
+func ParseTegrastatsLine(line string) (TegrastatsSample, error) {
+	sample := TegrastatsSample{
+		Temperatures: make(map[string]float64),
+		PowerRails:   make(map[string]TegrastatsPowerRail),
+		Fields:       make(map[string]string),
+		Unparsed:     make([]string, 0),
+		Raw:          line,
+	}
+	tokens := strings.Fields(line)
+	if len(tokens) == 0 {
+		return sample, ErrInvalidTegrastatsLine
+	}
+	if len(tokens) >= 2 {
+		if t, err := time.Parse(tegrastatsTimeLayout, tokens[0]+" "+tokens[1]); err == nil {
+			sample.Time = t
+			tokens = tokens[2:]
+		}
+	}
+	parsed := 0
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		next := ""
+		if i+1 < len(tokens) {
+			next = tokens[i+1]
+		}
+		switch {
+		case token == "RAM" || token == "SWAP":
+			mem, ok := parseTegrastatsMemory(next)
+			if !ok {
+				sample.Unparsed = append(sample.Unparsed, token)
+				continue
+			}
+			i++
+			if i+2 < len(tokens) && (tokens[i+1] == "(lfb" || tokens[i+1] == "(cached") {
+				parseTegrastatsMemoryDetail(&mem, tokens[i+1], tokens[i+2])
+				i += 2
+			}
+			if token == "RAM" {
+				sample.RAM = mem
+			} else {
+				sample.Swap = mem
+			}
+			parsed++
+		case token == "CPU" && strings.HasPrefix(next, "["):
+			sample.CPUs = parseTegrastatsCPUs(next)
+			i++
+			parsed++
+		case token == "EMC_FREQ" || token == "GR3D_FREQ":
+			engine, ok := parseTegrastatsEngine(next)
+			if !ok {
+				sample.Unparsed = append(sample.Unparsed, token)
+				continue
+			}
+			if token == "EMC_FREQ" {
+				sample.EMC = engine
+			} else {
+				sample.GPU = engine
+			}
+			i++
+			parsed++
+		case isTegrastatsTemperature(token):
+			name, value, _ := strings.Cut(token, "@")
+			temp, _ := strconv.ParseFloat(strings.TrimSuffix(value, "C"), 64)
+			sample.Temperatures[name] = temp
+			parsed++
+		case isTegrastatsFieldName(token) && isTegrastatsPowerRail(next):
+			sample.PowerRails[token] = parseTegrastatsPowerRail(next)
+			i++
+			parsed++
+		case isTegrastatsFieldName(token) && next != "" && !isTegrastatsFieldName(next) && !isTegrastatsTemperature(next):
+			value := next
+			i++
+			for strings.Contains(value, "(") && !strings.Contains(value, ")") && i+1 < len(tokens) {
+				i++
+				value += " " + tokens[i]
+			}
+			sample.Fields[token] = value
+		default:
+			sample.Unparsed = append(sample.Unparsed, token)
+		}
+	}
+	if parsed == 0 {
+		return sample, ErrInvalidTegrastatsLine
+	}
+	return sample, nil
+}
+
+// parseTegrastatsMemory parses used/total values such as 2370/7620MB or 0/252kB(lfb
+func parseTegrastatsMemory(s string) (TegrastatsMemory, bool) {
+	s, _, _ = strings.Cut(s, "(")
+	used, total, ok := strings.Cut(s, "/")
+	if !ok {
+		return TegrastatsMemory{}, false
+	}
+	totalMB, ok := parseTegrastatsSize(total)
+	if !ok {
+		return TegrastatsMemory{}, false
+	}
+	usedMB, err := strconv.Atoi(used)
+	if err != nil {
+		return TegrastatsMemory{}, false
+	}
+	if unit := strings.TrimLeft(total, "0123456789"); unit != "MB" {
+		usedMB, _ = parseTegrastatsSize(used + unit)
+	}
+	return TegrastatsMemory{UsedMB: usedMB, TotalMB: totalMB}, true
+}
+
+func parseTegrastatsMemoryDetail(mem *TegrastatsMemory, kind string, value string) {
+	value = strings.TrimSuffix(value, ")")
+	switch kind {
+	case "(lfb":
+		count, size, ok := strings.Cut(value, "x")
+		if !ok {
+			return
+		}
+		mem.LargestFreeBlocks, _ = strconv.Atoi(count)
+		mem.LargestFreeBlockMB, _ = parseTegrastatsSize(size)
+	case "(cached":
+		mem.CachedMB, _ = parseTegrastatsSize(value)
+	}
+}
+
+func parseTegrastatsSize(s string) (int, bool) {
+	number := strings.TrimRight(s, "kMGB")
+	multiplier, ok := tegrastatsMemoryUnitsInMB[s[len(number):]]
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.Atoi(number)
+	if err != nil {
+		return 0, false
+	}
+	return int(float64(v) * multiplier), true
+}
+
+// parseTegrastatsCPUs parses [2%@1510,1%@1510,off,off]
+func parseTegrastatsCPUs(s string) []TegrastatsCPU {
+	cpus := make([]TegrastatsCPU, 0)
+	for _, c := range strings.Split(strings.Trim(s, "[]"), ",") {
+		if c == "off" {
+			cpus = append(cpus, TegrastatsCPU{})
+			continue
+		}
+		load, freq, _ := strings.Cut(c, "@")
+		cpu := TegrastatsCPU{Online: true}
+		cpu.Load, _ = strconv.Atoi(strings.TrimSuffix(load, "%"))
+		cpu.FreqMHz, _ = strconv.Atoi(freq)
+		cpus = append(cpus, cpu)
+	}
+	return cpus
+}
+
+// parseTegrastatsEngine parses 0%@2133, 0%@[305,305] or just 0%
+func parseTegrastatsEngine(s string) (TegrastatsEngine, bool) {
+	load, freq, _ := strings.Cut(s, "@")
+	l, err := strconv.Atoi(strings.TrimSuffix(load, "%"))
+	if err != nil {
+		return TegrastatsEngine{}, false
+	}
+	engine := TegrastatsEngine{Load: l, FreqMHz: make([]int, 0)}
+	for _, f := range strings.Split(strings.Trim(freq, "[]"), ",") {
+		if v, err := strconv.Atoi(f); err == nil {
+			engine.FreqMHz = append(engine.FreqMHz, v)
+		}
+	}
+	return engine, true
+}
+
+func isTegrastatsTemperature(s string) bool {
+	name, value, ok := strings.Cut(s, "@")
+	if !ok || name == "" || !strings.HasSuffix(value, "C") {
+		return false
+	}
+	_, err := strconv.ParseFloat(strings.TrimSuffix(value, "C"), 64)
+	return err == nil
+}
+
+// isTegrastatsPowerRail matches 4047mW/4047mW as well as the older 1250/1250
+func isTegrastatsPowerRail(s string) bool {
+	current, average, ok := strings.Cut(s, "/")
+	if !ok {
+		return false
+	}
+	_, err1 := strconv.Atoi(strings.TrimSuffix(current, "mW"))
+	_, err2 := strconv.Atoi(strings.TrimSuffix(average, "mW"))
+	return err1 == nil && err2 == nil
+}
+
+func parseTegrastatsPowerRail(s string) TegrastatsPowerRail {
+	current, average, _ := strings.Cut(s, "/")
+	rail := TegrastatsPowerRail{}
+	rail.CurrentMW, _ = strconv.Atoi(strings.TrimSuffix(current, "mW"))
+	rail.AverageMW, _ = strconv.Atoi(strings.TrimSuffix(average, "mW"))
+	return rail
+}
+
+func isTegrastatsFieldName(s string) bool {
+	if s == "" || s[0] < 'A' || s[0] > 'Z' {
+		return false
+	}
+	for _, c := range s {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '_' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package nvidia
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidVersion = errors.New("invalid version")
+)
+
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	Build int
+}
+
+// ParseVersion reads the leading dotted numbers of s, so 35.4.1-20230801124926 and
+// 8.9.4.25-1+cuda12.2 both parse.
+func ParseVersion(s string) (Version, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "R")
+	end := 0
+	for end < len(s) && (s[end] == '.' || (s[end] >= '0' && s[end] <= '9')) {
+		end++
+	}
+	parts := strings.Split(strings.Trim(s[:end], "."), ".")
+	if len(parts) == 0 || parts[0] == "" || len(parts) > 4 {
+		return Version{}, ErrInvalidVersion
+	}
+	v := make([]int, 4)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return Version{}, ErrInvalidVersion
+		}
+		v[i] = n
+	}
+	return Version{Major: v[0], Minor: v[1], Patch: v[2], Build: v[3]}, nil
+}
+
+func (v Version) Compare(other Version) int {
+	a := []int{v.Major, v.Minor, v.Patch, v.Build}
+	b := []int{other.Major, other.Minor, other.Patch, other.Build}
+	for i := range a {
+		if a[i] < b[i] {
+			return -1
+		} else if a[i] > b[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+func (v Version) IsAtLeast(other Version) bool {
+	return v.Compare(other) >= 0
+}
+
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+func (v Version) String() string {
+	s := strconv.Itoa(v.Major) + "." + strconv.Itoa(v.Minor) + "." + strconv.Itoa(v.Patch)
+	if v.Build != 0 {
+		s += "." + strconv.Itoa(v.Build)
+	}
+	return s
+}
@@ -0,0 +1,23 @@
+package boardtype
+
+var (
+	Toradex                       = BoardType{Manufacturer: "Toradex", Model: "", SubModel: "", RAM: 0}
+	ToradexVerdin                 = BoardType{Manufacturer: "Toradex", Model: "Verdin", SubModel: "", RAM: 0, BaseModel: &Toradex}
+	ToradexVerdinIMX8MPlus        = BoardType{Manufacturer: "Toradex", Model: "Verdin", SubModel: "iMX8M Plus", RAM: 0, BaseModel: &ToradexVerdin}
+	ToradexVerdinIMX8MMini        = BoardType{Manufacturer: "Toradex", Model: "Verdin", SubModel: "iMX8M Mini", RAM: 0, BaseModel: &ToradexVerdin}
+	ToradexApalis                 = BoardType{Manufacturer: "Toradex", Model: "Apalis", SubModel: "", RAM: 0, BaseModel: &Toradex}
+	ToradexApalisIMX8             = BoardType{Manufacturer: "Toradex", Model: "Apalis", SubModel: "iMX8", RAM: 0, BaseModel: &ToradexApalis}
+	ToradexCarrier                = BoardType{Manufacturer: "Toradex", Model: "Carrier", SubModel: "", RAM: 0, BaseModel: &Toradex}
+	ToradexDahlia                 = BoardType{Manufacturer: "Toradex", Model: "Carrier", SubModel: "Dahlia", RAM: 0, BaseModel: &ToradexCarrier}
+	ToradexVerdinDevelopmentBoard = BoardType{Manufacturer: "Toradex", Model: "Carrier", SubModel: "Verdin Development Board", RAM: 0, BaseModel: &ToradexCarrier}
+	ToradexApalisEvaluationBoard  = BoardType{Manufacturer: "Toradex", Model: "Carrier", SubModel: "Apalis Evaluation Board", RAM: 0, BaseModel: &ToradexCarrier}
+	ToradexIxora                  = BoardType{Manufacturer: "Toradex", Model: "Carrier", SubModel: "Ixora", RAM: 0, BaseModel: &ToradexCarrier}
+	Variscite                     = BoardType{Manufacturer: "Variscite", Model: "", SubModel: "", RAM: 0}
+	VarisciteDART                 = BoardType{Manufacturer: "Variscite", Model: "DART", SubModel: "", RAM: 0, BaseModel: &Variscite}
+	VarisciteDARTMX8MPlus         = BoardType{Manufacturer: "Variscite", Model: "DART", SubModel: "MX8M-PLUS", RAM: 0, BaseModel: &VarisciteDART}
+	VarisciteDARTMX8MMini         = BoardType{Manufacturer: "Variscite", Model: "DART", SubModel: "MX8M-MINI", RAM: 0, BaseModel: &VarisciteDART}
+	VarisciteCarrier              = BoardType{Manufacturer: "Variscite", Model: "Carrier", SubModel: "", RAM: 0, BaseModel: &Variscite}
+	VarisciteDT8MCustomBoard      = BoardType{Manufacturer: "Variscite", Model: "Carrier", SubModel: "DT8MCustomBoard", RAM: 0, BaseModel: &VarisciteCarrier}
+	Coral                         = BoardType{Manufacturer: "Coral", Model: "", SubModel: "", RAM: 0}
+	CoralDevBoard                 = BoardType{Manufacturer: "Coral", Model: "Dev Board", SubModel: "", RAM: 0, BaseModel: &Coral}
+)
@@ -0,0 +1,142 @@
+package nxp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+const (
+	configBlockTagValid   = 0xcf01
+	configBlockTagMAC     = 0x0000
+	configBlockTagHW      = 0x0008
+	configBlockTagInvalid = 0xffff
+	configBlockFlagValid  = 0x1
+	configBlockSize       = 512
+	deviceTreeDir         = "/proc/device-tree"
+	// The module config block is the last 512 bytes of the first eMMC boot partition.
+	mmcBootPartitionGlob = "/dev/mmcblk*boot0"
+)
+
+var (
+	ErrConfigBlockNotFound = errors.New("config block not found")
+	ErrInvalidConfigBlock  = errors.New("invalid config block")
+)
+
+// ConfigBlock is the Toradex config block, the module one is in the eMMC boot partition and the
+// carrier one in the carrier EEPROM. U-Boot copies both into the root node of the device tree
+// (tdx-common.c), the module one is read from the eMMC when U-Boot didn't.
+type ConfigBlock struct {
+	ProductID    string
+	Revision     string
+	SerialNumber string
+	MAC          net.HardwareAddr
+}
+
+func GetConfigBlock(logger *slog.Logger) (*ConfigBlock, error) {
+	block, err := readConfigBlock(logger, deviceTreeDir, "toradex,product-id", "toradex,board-rev", "serial-number")
+	if err == nil {
+		return block, nil
+	}
+	paths, _ := filepath.Glob(mmcBootPartitionGlob)
+	return readRawConfigBlock(logger, paths)
+}
+
+func GetCarrierConfigBlock(logger *slog.Logger) (*ConfigBlock, error) {
+	return readConfigBlock(logger, deviceTreeDir, "toradex,carrier-product-id", "toradex,carrier-board-rev", "toradex,carrier-serial-number")
+}
+
+func readConfigBlock(logger *slog.Logger, dir string, productID, revision, serial string) (*ConfigBlock, error) {
+	id, err := identifier.ReadDeviceTreeString(logger, filepath.Join(dir, productID))
+	if err != nil {
+		return nil, ErrConfigBlockNotFound
+	}
+	block := &ConfigBlock{ProductID: id}
+	block.Revision, _ = identifier.ReadDeviceTreeString(logger, filepath.Join(dir, revision))
+	block.SerialNumber, _ = identifier.ReadDeviceTreeString(logger, filepath.Join(dir, serial))
+	logger.Debug("config block", slog.String("productID", block.ProductID), slog.String("revision", block.Revision), slog.String("serial", block.SerialNumber))
+	return block, nil
+}
+
+func readRawConfigBlock(logger *slog.Logger, paths []string) (*ConfigBlock, error) {
+	for _, path := range paths {
+		data, err := readConfigBlockFromEnd(path)
+		if err != nil {
+			logger.Debug("cannot read config block", slog.String("path", path), slog.Any("error", err))
+			continue
+		}
+		block, err := ParseConfigBlock(data)
+		if err != nil {
+			logger.Debug("cannot parse config block", slog.String("path", path), slog.Any("error", err))
+			continue
+		}
+		logger.Debug("config block", slog.String("path", path), slog.String("productID", block.ProductID), slog.String("revision", block.Revision), slog.String("serial", block.SerialNumber))
+		return block, nil
+	}
+	return nil, ErrConfigBlockNotFound
+}
+
+func readConfigBlockFromEnd(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(-configBlockSize, io.SeekEnd); err != nil {
+		return nil, err
+	}
+	data := make([]byte, configBlockSize)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ParseConfigBlock parses a raw config block, a list of tags where each tag word holds the
+// length in words, the flags and the id.
+func ParseConfigBlock(data []byte) (*ConfigBlock, error) {
+	if len(data) < 4 {
+		return nil, ErrInvalidConfigBlock
+	}
+	first := binary.LittleEndian.Uint32(data[0:4])
+	if first>>16 != configBlockTagValid || (first>>14)&0x3 != configBlockFlagValid {
+		return nil, ErrInvalidConfigBlock
+	}
+	block := &ConfigBlock{}
+	found := false
+	for offset := 4; offset+4 <= len(data); {
+		tag := binary.LittleEndian.Uint32(data[offset : offset+4])
+		id := tag >> 16
+		length := int(tag&0x3fff) * 4
+		offset += 4
+		if id == configBlockTagInvalid || (tag>>14)&0x3 != configBlockFlagValid || offset+length > len(data) {
+			break
+		}
+		payload := data[offset : offset+length]
+		switch {
+		case id == configBlockTagHW && length >= 8:
+			major := binary.LittleEndian.Uint16(payload[0:2])
+			minor := binary.LittleEndian.Uint16(payload[2:4])
+			assembly := binary.LittleEndian.Uint16(payload[4:6])
+			block.ProductID = fmt.Sprintf("%04d", binary.LittleEndian.Uint16(payload[6:8]))
+			block.Revision = fmt.Sprintf("V%d.%d%c", major, minor, 'A'+rune(assembly))
+			found = true
+		case id == configBlockTagMAC && length >= 6:
+			block.MAC = net.HardwareAddr(append([]byte(nil), payload[0:6]...))
+			nic := uint32(payload[3])<<16 | uint32(payload[4])<<8 | uint32(payload[5])
+			block.SerialNumber = fmt.Sprintf("%08d", nic)
+		}
+		offset += length
+	}
+	if !found {
+		return nil, ErrInvalidConfigBlock
+	}
+	return block, nil
+}
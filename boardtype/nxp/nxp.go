@@ -0,0 +1,171 @@
+package nxp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+func init() {
+	identifier.RegisterBoardIdentifier(NewNXPIdentifier)
+}
+
+var (
+	ErrCannotIdentifyBoard = errors.New("cannot identify NXP board")
+	ErrUniqueIDNotFound    = errors.New("OCOTP unique ID not found")
+	ocotpPaths             = []string{"/sys/bus/nvmem/devices/imx-ocotp0/nvmem"}
+)
+
+type nxpPart struct {
+	Compatible string
+	Model      string
+	Type       boardtype.SBC
+}
+
+// Parts are matched by compatible or by a substring of the device tree model, vendor kernels
+// don't always carry a specific compatible.
+var nxpModules = []nxpPart{
+	{"toradex,verdin-imx8mp", "Verdin iMX8M Plus", boardtype.ToradexVerdinIMX8MPlus},
+	{"toradex,verdin-imx8mm", "Verdin iMX8M Mini", boardtype.ToradexVerdinIMX8MMini},
+	{"toradex,apalis-imx8", "Apalis iMX8", boardtype.ToradexApalisIMX8},
+	{"", "DART-MX8M-PLUS", boardtype.VarisciteDARTMX8MPlus},
+	{"", "DART-MX8M-MINI", boardtype.VarisciteDARTMX8MMini},
+	{"fsl,imx8mq-phanbell", "Phanbell", boardtype.CoralDevBoard},
+}
+
+var nxpCarriers = []nxpPart{
+	{"toradex,verdin-imx8mp-dahlia", "Dahlia", boardtype.ToradexDahlia},
+	{"toradex,verdin-imx8mm-dahlia", "Dahlia", boardtype.ToradexDahlia},
+	{"toradex,verdin-imx8mp-dev", "Verdin Development Board", boardtype.ToradexVerdinDevelopmentBoard},
+	{"toradex,verdin-imx8mm-dev", "Verdin Development Board", boardtype.ToradexVerdinDevelopmentBoard},
+	{"toradex,apalis-imx8-eval", "Apalis Evaluation Board", boardtype.ToradexApalisEvaluationBoard},
+	{"", "Ixora", boardtype.ToradexIxora},
+	{"", "DT8MCustomBoard", boardtype.VarisciteDT8MCustomBoard},
+}
+
+// NXPSystem is an i.MX SoM on its carrier, Carrier is nil for single board computers and when
+// the carrier isn't known. The config blocks are only present on Toradex modules.
+type NXPSystem struct {
+	Module             boardtype.SBC
+	Carrier            boardtype.SBC
+	SoC                *identifier.SoC
+	UniqueID           string
+	ConfigBlock        *ConfigBlock
+	CarrierConfigBlock *ConfigBlock
+}
+
+type nxpIdentifier struct {
+	logger *slog.Logger
+}
+
+func NewNXPIdentifier(logger *slog.Logger) identifier.BoardIdentifier {
+	logger.Debug("initializing NXP identifier")
+	newLogger := logger.With(slog.String("source", "NXP"))
+	return nxpIdentifier{
+		logger: newLogger,
+	}
+}
+
+func (r nxpIdentifier) Name() string {
+	return "NXP Identifier"
+}
+
+func (r nxpIdentifier) GetBoardType() (boardtype.SBC, error) {
+	compatible, err := identifier.GetDeviceTreeCompatible(r.logger)
+	if err != nil {
+		return nil, ErrCannotIdentifyBoard
+	}
+	model, _ := identifier.GetDeviceTreeModel(r.logger)
+	module, _ := getNXPParts(compatible, model)
+	if module == nil {
+		r.logger.Debug("unknown board", slog.String("model", model))
+		return nil, ErrCannotIdentifyBoard
+	}
+	return module, nil
+}
+
+// GetNXPSystem reports the module and carrier along with the SoC from soc0, the unique ID from
+// the OCOTP and the Toradex config blocks when U-Boot has passed them on.
+func GetNXPSystem(logger *slog.Logger) (*NXPSystem, error) {
+	compatible, err := identifier.GetDeviceTreeCompatible(logger)
+	if err != nil {
+		return nil, ErrCannotIdentifyBoard
+	}
+	model, _ := identifier.GetDeviceTreeModel(logger)
+	system := &NXPSystem{}
+	system.Module, system.Carrier = getNXPParts(compatible, model)
+	if system.Module == nil {
+		return nil, ErrCannotIdentifyBoard
+	}
+	if system.SoC, err = identifier.GetSoC(logger); err != nil {
+		logger.Debug("cannot identify SoC", slog.Any("error", err))
+	}
+	if system.UniqueID, err = readUniqueID(logger, ocotpPaths); err != nil && system.SoC != nil {
+		system.UniqueID = system.SoC.SerialNumber
+	}
+	system.ConfigBlock, _ = GetConfigBlock(logger)
+	system.CarrierConfigBlock, _ = GetCarrierConfigBlock(logger)
+	return system, nil
+}
+
+func getNXPParts(compatible []string, model string) (boardtype.SBC, boardtype.SBC) {
+	if !isNXP(compatible) {
+		return nil, nil
+	}
+	return matchNXPPart(nxpModules, compatible, model), matchNXPPart(nxpCarriers, compatible, model)
+}
+
+func matchNXPPart(parts []nxpPart, compatible []string, model string) boardtype.SBC {
+	for _, c := range compatible {
+		for _, p := range parts {
+			if p.Compatible != "" && c == p.Compatible {
+				return p.Type
+			}
+		}
+	}
+	for _, p := range parts {
+		if model != "" && strings.Contains(model, p.Model) {
+			return p.Type
+		}
+	}
+	return nil
+}
+
+func isNXP(compatible []string) bool {
+	for _, c := range compatible {
+		if strings.HasPrefix(c, "fsl,imx") {
+			return true
+		}
+	}
+	return false
+}
+
+// readUniqueID reads the 64 bit unique ID from the OCOTP, UID_LOW and UID_HIGH are the second
+// and third fuse words on the i.MX8M.
+func readUniqueID(logger *slog.Logger, paths []string) (string, error) {
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			logger.Debug("cannot read OCOTP", slog.String("path", path), slog.Any("error", err))
+			continue
+		}
+		if len(data) < 12 {
+			continue
+		}
+		low := binary.LittleEndian.Uint32(data[4:8])
+		high := binary.LittleEndian.Uint32(data[8:12])
+		if low == 0 && high == 0 {
+			continue
+		}
+		id := fmt.Sprintf("%08X%08X", high, low)
+		logger.Debug("OCOTP unique ID", slog.String("id", id))
+		return id, nil
+	}
+	return "", ErrUniqueIDNotFound
+}
@@ -0,0 +1,110 @@
+package nxp
+
+import (
+	"encoding/binary"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+)
+
+func TestGetNXPParts(t *testing.T) {
+	tests := []struct {
+		compatible []string
+		model      string
+		module     boardtype.SBC
+		carrier    boardtype.SBC
+	}{
+		{[]string{"toradex,verdin-imx8mp-wifi-dahlia", "toradex,verdin-imx8mp-dahlia", "toradex,verdin-imx8mp-wifi", "toradex,verdin-imx8mp", "fsl,imx8mp"}, "Toradex Verdin iMX8M Plus WB on Dahlia Board", boardtype.ToradexVerdinIMX8MPlus, boardtype.ToradexDahlia},
+		{[]string{"toradex,verdin-imx8mm-nonwifi-dev", "toradex,verdin-imx8mm-dev", "toradex,verdin-imx8mm-nonwifi", "toradex,verdin-imx8mm", "fsl,imx8mm"}, "Toradex Verdin iMX8M Mini on Verdin Development Board", boardtype.ToradexVerdinIMX8MMini, boardtype.ToradexVerdinDevelopmentBoard},
+		{[]string{"toradex,apalis-imx8-ixora-v1.2", "toradex,apalis-imx8", "fsl,imx8qm"}, "Toradex Apalis iMX8QM on Apalis Ixora V1.2 Carrier Board", boardtype.ToradexApalisIMX8, boardtype.ToradexIxora},
+		{[]string{"variscite,imx8mp-var-dart", "fsl,imx8mp"}, "Variscite DART-MX8M-PLUS on DT8MCustomBoard 2.x", boardtype.VarisciteDARTMX8MPlus, boardtype.VarisciteDT8MCustomBoard},
+		{[]string{"fsl,imx8mq-phanbell", "fsl,imx8mq"}, "Freescale i.MX8MQ Phanbell", boardtype.CoralDevBoard, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.model, func(t *testing.T) {
+			module, carrier := getNXPParts(test.compatible, test.model)
+			assert.Equal(t, test.module, module)
+			assert.Equal(t, test.carrier, carrier)
+		})
+	}
+	module, carrier := getNXPParts([]string{"radxa,rock-5b", "rockchip,rk3588"}, "Toradex Verdin iMX8M Plus")
+	assert.Nil(t, module)
+	assert.Nil(t, carrier)
+}
+
+func configBlockTag(id uint32, words int) []byte {
+	return binary.LittleEndian.AppendUint32(nil, id<<16|configBlockFlagValid<<14|uint32(words))
+}
+
+func buildConfigBlock() []byte {
+	data := configBlockTag(configBlockTagValid, 0)
+	data = append(data, configBlockTag(configBlockTagMAC, 2)...)
+	data = append(data, 0x00, 0x14, 0x2d, 0x68, 0x8e, 0x34, 0xff, 0xff)
+	data = append(data, configBlockTag(configBlockTagHW, 2)...)
+	data = binary.LittleEndian.AppendUint16(data, 1)
+	data = binary.LittleEndian.AppendUint16(data, 1)
+	data = binary.LittleEndian.AppendUint16(data, 0)
+	data = binary.LittleEndian.AppendUint16(data, 58)
+	return append(data, 0xff, 0xff, 0xff, 0xff)
+}
+
+func TestParseConfigBlock(t *testing.T) {
+	block, err := ParseConfigBlock(buildConfigBlock())
+	require.NoError(t, err)
+	assert.Equal(t, "0058", block.ProductID)
+	assert.Equal(t, "V1.1A", block.Revision)
+	assert.Equal(t, "06852148", block.SerialNumber)
+	assert.Equal(t, "00:14:2d:68:8e:34", block.MAC.String())
+
+	_, err = ParseConfigBlock(make([]byte, 64))
+	assert.Equal(t, ErrInvalidConfigBlock, err)
+}
+
+func TestReadConfigBlock(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "toradex,product-id"), []byte("0058\x00"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "toradex,board-rev"), []byte("V1.1A\x00"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "serial-number"), []byte("06852148\x00"), 0644))
+
+	block, err := readConfigBlock(logger, dir, "toradex,product-id", "toradex,board-rev", "serial-number")
+	require.NoError(t, err)
+	assert.Equal(t, &ConfigBlock{ProductID: "0058", Revision: "V1.1A", SerialNumber: "06852148"}, block)
+
+	_, err = readConfigBlock(logger, dir, "toradex,carrier-product-id", "toradex,carrier-board-rev", "toradex,carrier-serial-number")
+	assert.Equal(t, ErrConfigBlockNotFound, err)
+
+	boot0 := make([]byte, 4096)
+	copy(boot0[len(boot0)-configBlockSize:], buildConfigBlock())
+	path := filepath.Join(dir, "mmcblk2boot0")
+	require.NoError(t, os.WriteFile(path, boot0, 0644))
+	block, err = readRawConfigBlock(logger, []string{filepath.Join(dir, "mmcblk0boot0"), path})
+	require.NoError(t, err)
+	assert.Equal(t, "0058", block.ProductID)
+	assert.Equal(t, "06852148", block.SerialNumber)
+
+	_, err = readRawConfigBlock(logger, []string{filepath.Join(dir, "serial-number")})
+	assert.Equal(t, ErrConfigBlockNotFound, err)
+}
+
+func TestReadUniqueID(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	data := make([]byte, 1024)
+	binary.LittleEndian.PutUint32(data[4:8], 0x8a2f3d91)
+	binary.LittleEndian.PutUint32(data[8:12], 0x0b2e1b1c)
+	path := filepath.Join(t.TempDir(), "nvmem")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	id, err := readUniqueID(logger, []string{path})
+	require.NoError(t, err)
+	assert.Equal(t, "0B2E1B1C8A2F3D91", id)
+
+	_, err = readUniqueID(logger, []string{filepath.Join(t.TempDir(), "missing")})
+	assert.Equal(t, ErrUniqueIDNotFound, err)
+}
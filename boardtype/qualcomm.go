@@ -0,0 +1,13 @@
+package boardtype
+
+var (
+	Qualcomm                = BoardType{Manufacturer: "Qualcomm", Model: "", SubModel: "", RAM: 0}
+	QualcommRobotics        = BoardType{Manufacturer: "Qualcomm", Model: "Robotics", SubModel: "", RAM: 0, BaseModel: &Qualcomm}
+	QualcommRB1             = BoardType{Manufacturer: "Qualcomm", Model: "Robotics", SubModel: "RB1", RAM: 2048, BaseModel: &QualcommRobotics}
+	QualcommRB2             = BoardType{Manufacturer: "Qualcomm", Model: "Robotics", SubModel: "RB2", RAM: 4096, BaseModel: &QualcommRobotics}
+	QualcommRB3Gen2         = BoardType{Manufacturer: "Qualcomm", Model: "Robotics", SubModel: "RB3 Gen2", RAM: 6144, BaseModel: &QualcommRobotics}
+	QualcommRB5             = BoardType{Manufacturer: "Qualcomm", Model: "Robotics", SubModel: "RB5", RAM: 8192, BaseModel: &QualcommRobotics}
+	QualcommDragonBoard     = BoardType{Manufacturer: "Qualcomm", Model: "DragonBoard", SubModel: "", RAM: 0, BaseModel: &Qualcomm}
+	QualcommDragonBoard410c = BoardType{Manufacturer: "Qualcomm", Model: "DragonBoard", SubModel: "410c", RAM: 1024, BaseModel: &QualcommDragonBoard}
+	QualcommDragonBoard845c = BoardType{Manufacturer: "Qualcomm", Model: "DragonBoard", SubModel: "845c", RAM: 4096, BaseModel: &QualcommDragonBoard}
+)
@@ -0,0 +1,96 @@
+package qualcomm
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+func init() {
+	identifier.RegisterBoardIdentifier(NewQualcommIdentifier)
+}
+
+var (
+	ErrCannotIdentifyBoard = errors.New("cannot identify Qualcomm board")
+)
+
+type qualcommBoard struct {
+	Compatible string
+	Model      string
+	Type       boardtype.SBC
+}
+
+// The DragonBoard 845c was released as the Robotics RB3, its compatible is Thundercomm's.
+var qualcommBoards = []qualcommBoard{
+	{"qcom,qrb2210-rb1", "Robotics RB1", boardtype.QualcommRB1},
+	{"qcom,qrb4210-rb2", "Robotics RB2", boardtype.QualcommRB2},
+	{"qcom,qcs6490-rb3gen2", "RB3gen2", boardtype.QualcommRB3Gen2},
+	{"qcom,qrb5165-rb5", "Robotics RB5", boardtype.QualcommRB5},
+	{"qcom,apq8016-sbc", "APQ 8016 SBC", boardtype.QualcommDragonBoard410c},
+	{"thundercomm,db845c", "Dragonboard 845c", boardtype.QualcommDragonBoard845c},
+	{"qcom,sdm845-db845c", "DB845c", boardtype.QualcommDragonBoard845c},
+}
+
+type qualcommIdentifier struct {
+	logger *slog.Logger
+}
+
+func NewQualcommIdentifier(logger *slog.Logger) identifier.BoardIdentifier {
+	logger.Debug("initializing Qualcomm identifier")
+	newLogger := logger.With(slog.String("source", "Qualcomm"))
+	return qualcommIdentifier{
+		logger: newLogger,
+	}
+}
+
+func (r qualcommIdentifier) Name() string {
+	return "Qualcomm Identifier"
+}
+
+func (r qualcommIdentifier) GetBoardType() (boardtype.SBC, error) {
+	compatible, err := identifier.GetDeviceTreeCompatible(r.logger)
+	if err != nil {
+		return nil, ErrCannotIdentifyBoard
+	}
+	model, _ := identifier.GetDeviceTreeModel(r.logger)
+	board, ok := getQualcommBoard(compatible, model)
+	if !ok {
+		r.logger.Debug("unknown board", slog.String("model", model))
+		return nil, ErrCannotIdentifyBoard
+	}
+	return board.Type, nil
+}
+
+// The Qualcomm models carry more than the board name, Qualcomm Technologies, Inc. Robotics RB5
+// for example, so the board name is searched for anywhere in the model, and only when a qcom
+// entry is in the compatible list.
+func getQualcommBoard(compatible []string, model string) (qualcommBoard, bool) {
+	for _, c := range compatible {
+		for _, b := range qualcommBoards {
+			if c == b.Compatible {
+				return b, true
+			}
+		}
+	}
+	if !isQualcomm(compatible) {
+		return qualcommBoard{}, false
+	}
+	for _, b := range qualcommBoards {
+		if strings.Contains(strings.ToLower(model), strings.ToLower(b.Model)) {
+			return b, true
+		}
+	}
+	return qualcommBoard{}, false
+}
+
+func isQualcomm(compatible []string) bool {
+	for _, c := range compatible {
+		if strings.HasPrefix(c, "qcom,") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package qualcomm
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+func TestGetQualcommBoard(t *testing.T) {
+	tests := []struct {
+		compatible []string
+		model      string
+		expected   boardtype.SBC
+		family     boardtype.SBC
+	}{
+		{[]string{"qcom,qcs6490-rb3gen2", "qcom,qcs6490"}, "Qualcomm Technologies, Inc. QCS6490 RB3gen2", boardtype.QualcommRB3Gen2, boardtype.QualcommRobotics},
+		{[]string{"qcom,qrb5165-rb5", "qcom,sm8250"}, "Qualcomm Technologies, Inc. Robotics RB5", boardtype.QualcommRB5, boardtype.QualcommRobotics},
+		{[]string{"qcom,qrb2210-rb1", "qcom,qrb2210", "qcom,qcm2290"}, "Qualcomm Technologies, Inc. Robotics RB1", boardtype.QualcommRB1, boardtype.QualcommRobotics},
+		{[]string{"qcom,apq8016-sbc", "qcom,apq8016"}, "Qualcomm Technologies, Inc. APQ 8016 SBC", boardtype.QualcommDragonBoard410c, boardtype.QualcommDragonBoard},
+		{[]string{"thundercomm,db845c", "qcom,sdm845"}, "Thundercomm Dragonboard 845c", boardtype.QualcommDragonBoard845c, boardtype.QualcommDragonBoard},
+		{[]string{"qcom,qrb4210"}, "Qualcomm Technologies, Inc. Robotics RB2", boardtype.QualcommRB2, boardtype.Qualcomm},
+	}
+	for _, test := range tests {
+		t.Run(test.model, func(t *testing.T) {
+			board, ok := getQualcommBoard(test.compatible, test.model)
+			require.True(t, ok)
+			assert.Equal(t, test.expected, board.Type)
+			assert.True(t, board.Type.IsBoardType(test.family))
+		})
+	}
+	_, ok := getQualcommBoard([]string{"radxa,rock-5b", "rockchip,rk3588"}, "Robotics RB5")
+	assert.False(t, ok)
+}
+
+func TestParseSoC(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	soc, err := parseSoC(logger, &identifier.SoC{Family: "Snapdragon", Machine: "QCS6490", ID: "497", Revision: "1.0", SerialNumber: "1782309457"})
+	require.NoError(t, err)
+	assert.Equal(t, 497, soc.ID)
+	assert.Equal(t, "1782309457", soc.SerialNumber)
+	assert.Equal(t, "QCS6490 rev 1.0", soc.String())
+
+	_, err = parseSoC(logger, &identifier.SoC{Family: "Tegra", ID: "35"})
+	assert.Equal(t, ErrNotQualcomm, err)
+}
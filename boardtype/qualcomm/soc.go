@@ -0,0 +1,54 @@
+package qualcomm
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+var (
+	ErrNotQualcomm = errors.New("SoC is not a Qualcomm SoC")
+)
+
+// SoC is the Qualcomm SoC as the socinfo driver reports it, ID is the numeric part ID and
+// Machine is the part name the kernel has for it, for example QCS6490.
+type SoC struct {
+	Family       string
+	Machine      string
+	ID           int
+	Revision     string
+	SerialNumber string
+}
+
+func (s SoC) String() string {
+	if s.Revision == "" {
+		return s.Machine
+	}
+	return s.Machine + " rev " + s.Revision
+}
+
+func GetSoC(logger *slog.Logger) (*SoC, error) {
+	soc, err := identifier.GetSoC(logger)
+	if err != nil {
+		return nil, err
+	}
+	return parseSoC(logger, soc)
+}
+
+func parseSoC(logger *slog.Logger, soc *identifier.SoC) (*SoC, error) {
+	if soc.Family != "Snapdragon" {
+		logger.Debug("SoC is not a Qualcomm SoC", slog.String("family", soc.Family))
+		return nil, ErrNotQualcomm
+	}
+	ret := &SoC{Family: soc.Family, Machine: soc.Machine, Revision: soc.Revision, SerialNumber: soc.SerialNumber}
+	if id, err := strconv.Atoi(soc.ID); err == nil {
+		ret.ID = id
+	}
+	if ret.Machine == "" {
+		ret.Machine = soc.Name
+	}
+	logger.Debug("Qualcomm SoC", slog.String("machine", ret.Machine), slog.Int("id", ret.ID), slog.String("revision", ret.Revision))
+	return ret, nil
+}
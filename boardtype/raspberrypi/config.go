@@ -0,0 +1,219 @@
+package raspberrypi
+
+import (
+	"bufio"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+const (
+	maxConfigIncludeDepth = 16
+)
+
+var (
+	ErrConfigNotFound       = errors.New("config.txt not found")
+	ErrConfigIncludeTooDeep = errors.New("config.txt includes are nested too deeply")
+	configPaths             = []string{"/boot/firmware/config.txt", "/boot/config.txt"}
+)
+
+type configModel struct {
+	Model     string
+	BoardType int
+	Filters   []string
+}
+
+// The board-type values are the type field of the new style revision code. Model is a prefix
+// of the device tree model, so the longer names have to come first.
+var configModels = []configModel{
+	{"Raspberry Pi 3 Model B Plus", 0x0d, []string{"pi3", "pi3+"}},
+	{"Raspberry Pi 3 Model A Plus", 0x0e, []string{"pi3", "pi3+"}},
+	{"Raspberry Pi 3 Model B", 0x08, []string{"pi3"}},
+	{"Raspberry Pi 4 Model B", 0x11, []string{"pi4"}},
+	{"Raspberry Pi 400", 0x13, []string{"pi4", "pi400"}},
+	{"Raspberry Pi Compute Module 4S", 0x15, []string{"pi4", "cm4s"}},
+	{"Raspberry Pi Compute Module 4", 0x14, []string{"pi4", "cm4"}},
+	{"Raspberry Pi 5 Model B", 0x17, []string{"pi5"}},
+	{"Raspberry Pi Compute Module 5", 0x18, []string{"pi5", "cm5"}},
+}
+
+type ConfigDirective struct {
+	Key   string
+	Value string
+}
+
+// Config is the effective config.txt for a board, Settings holds the last value of every key
+// that applies and DeviceTree holds the dtoverlay and dtparam lines in the order they apply.
+type Config struct {
+	Settings   map[string]string
+	DeviceTree []ConfigDirective
+}
+
+func (c Config) Overlays() []string {
+	overlays := make([]string, 0)
+	for _, d := range c.DeviceTree {
+		if d.Key == "dtoverlay" {
+			overlays = append(overlays, d.Value)
+		}
+	}
+	return overlays
+}
+
+func (c Config) Params() []string {
+	params := make([]string, 0)
+	for _, d := range c.DeviceTree {
+		if d.Key == "dtparam" {
+			params = append(params, d.Value)
+		}
+	}
+	return params
+}
+
+// GetConfig matches the filters against the device tree model rather than the identified board,
+// which falls back to the Pi 4B or 5B when the RAM of a Compute Module can't be read.
+func GetConfig(logger *slog.Logger) (*Config, error) {
+	model, err := identifier.GetDeviceTreeModel(logger)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range configPaths {
+		if _, err := os.Stat(path); err == nil {
+			return ParseConfig(logger, path, model)
+		}
+	}
+	logger.Debug("config.txt not found", slog.Any("paths", configPaths))
+	return nil, ErrConfigNotFound
+}
+
+// ParseConfig evaluates the conditional filters in the config.txt at path for the board with the
+// given device tree model, for example Raspberry Pi 5 Model B Rev 1.0. Filters
+// that depend on runtime state (EDID, gpio, serial number and tryboot) never match, HDMI port
+// filters always match as the firmware applies those settings to the named port.
+func ParseConfig(logger *slog.Logger, path string, model string) (*Config, error) {
+	config := &Config{
+		Settings:   make(map[string]string),
+		DeviceTree: make([]ConfigDirective, 0),
+	}
+	filters := make(map[string]bool)
+	if err := parseConfigFile(logger, path, model, config, filters, 0); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func parseConfigFile(logger *slog.Logger, path string, model string, config *Config, filters map[string]bool, depth int) error {
+	if depth > maxConfigIncludeDepth {
+		return ErrConfigIncludeTooDeep
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		logger.Debug("cannot open config file", slog.String("path", path), slog.Any("error", err))
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			applyConfigFilter(model, filters, strings.TrimSpace(line[1:len(line)-1]))
+			continue
+		}
+		if !configFiltersMatch(filters) {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			key, value, _ = strings.Cut(line, " ")
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		switch key {
+		case "include":
+			include := value
+			if !filepath.IsAbs(include) {
+				include = filepath.Join(filepath.Dir(path), include)
+			}
+			logger.Debug("including config file", slog.String("path", include))
+			if err := parseConfigFile(logger, include, model, config, filters, depth+1); err != nil {
+				return err
+			}
+		case "dtoverlay", "dtparam":
+			if value != "" {
+				config.DeviceTree = append(config.DeviceTree, ConfigDirective{Key: key, Value: value})
+			}
+		default:
+			config.Settings[key] = value
+		}
+	}
+	return scanner.Err()
+}
+
+func applyConfigFilter(model string, filters map[string]bool, filter string) {
+	lower := strings.ToLower(filter)
+	switch {
+	case lower == "all":
+		clear(filters)
+	case lower == "none":
+		filters["none"] = false
+	case lower == "tryboot":
+		filters["tryboot"] = false
+	case strings.HasPrefix(lower, "hdmi:"):
+		filters["hdmi"] = true
+	case strings.HasPrefix(lower, "edid="):
+		filters["edid"] = false
+	case strings.HasPrefix(lower, "gpio"):
+		filters["gpio"] = false
+	case strings.HasPrefix(lower, "board-type="):
+		boardType, err := strconv.ParseInt(strings.TrimPrefix(lower, "board-type="), 0, 32)
+		filters["model"] = err == nil && modelMatchesConfigType(model, int(boardType))
+	case strings.HasPrefix(lower, "0x"):
+		filters["serial"] = false
+	default:
+		filters["model"] = modelMatchesConfigFilter(model, lower)
+	}
+}
+
+func configFiltersMatch(filters map[string]bool) bool {
+	for _, match := range filters {
+		if !match {
+			return false
+		}
+	}
+	return true
+}
+
+func getConfigModel(model string) (configModel, bool) {
+	for _, m := range configModels {
+		if strings.HasPrefix(model, m.Model) {
+			return m, true
+		}
+	}
+	return configModel{}, false
+}
+
+func modelMatchesConfigFilter(model string, filter string) bool {
+	m, ok := getConfigModel(model)
+	if !ok {
+		return false
+	}
+	for _, f := range m.Filters {
+		if f == filter {
+			return true
+		}
+	}
+	return false
+}
+
+func modelMatchesConfigType(model string, boardType int) bool {
+	m, ok := getConfigModel(model)
+	return ok && m.BoardType == boardType
+}
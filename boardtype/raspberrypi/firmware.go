@@ -0,0 +1,210 @@
+package raspberrypi
+
+import (
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+const (
+	bootloaderDeviceTreeDir = "/proc/device-tree/chosen/bootloader"
+)
+
+var (
+	ErrInvalidFirmwareVersion   = errors.New("invalid firmware version")
+	ErrInvalidBootloaderVersion = errors.New("invalid bootloader version")
+	firmwareDateLayouts         = []string{"Jan 2 2006 15:04:05", "2006/01/02 15:04:05"}
+)
+
+// FirmwareVersion is the VideoCore firmware as reported by vcgencmd version.
+type FirmwareVersion struct {
+	Date    time.Time
+	Hash    string
+	Clean   bool
+	Release string
+	Variant string
+}
+
+func (f FirmwareVersion) Compare(other FirmwareVersion) int {
+	return f.Date.Compare(other.Date)
+}
+
+func (f FirmwareVersion) IsAtLeast(date time.Time) bool {
+	return !f.Date.Before(date)
+}
+
+// BootloaderVersion is the EEPROM bootloader, only present on the Pi 4 and later.
+type BootloaderVersion struct {
+	Date         time.Time
+	Hash         string
+	UpdateTime   time.Time
+	Capabilities uint32
+	Config       map[string]string
+}
+
+func (b BootloaderVersion) Compare(other BootloaderVersion) int {
+	return b.Date.Compare(other.Date)
+}
+
+func (b BootloaderVersion) IsAtLeast(date time.Time) bool {
+	return !b.Date.Before(date)
+}
+
+func GetFirmwareVersion(logger *slog.Logger) (*FirmwareVersion, error) {
+	output, err := vcgencmd(logger, "version")
+	if err != nil {
+		return nil, err
+	}
+	return parseVcgencmdVersionOutput(logger, output)
+}
+
+func GetBootloaderVersion(logger *slog.Logger) (*BootloaderVersion, error) {
+	version, err := readBootloaderVersion(logger, bootloaderDeviceTreeDir)
+	if err != nil {
+		logger.Debug("cannot read bootloader version from device tree, falling back to vcgencmd", slog.Any("error", err))
+		output, err := vcgencmd(logger, "bootloader_version")
+		if err != nil {
+			return nil, err
+		}
+		version, err = parseVcgencmdBootloaderVersionOutput(logger, output)
+		if err != nil {
+			return nil, err
+		}
+	}
+	output, err := vcgencmd(logger, "bootloader_config")
+	if err != nil {
+		logger.Debug("bootloader config is not available", slog.Any("error", err))
+		return version, nil
+	}
+	version.Config = parseBootloaderConfig(output)
+	return version, nil
+}
+
+func readBootloaderVersion(logger *slog.Logger, dir string) (*BootloaderVersion, error) {
+	hash, err := identifier.ReadDeviceTreeString(logger, filepath.Join(dir, "version"))
+	if err != nil {
+		return nil, err
+	}
+	buildTimestamp, err := identifier.ReadDeviceTreeUint32(logger, filepath.Join(dir, "build-timestamp"))
+	if err != nil {
+		return nil, err
+	}
+	version := &BootloaderVersion{
+		Date: time.Unix(int64(buildTimestamp), 0).UTC(),
+		Hash: hash,
+	}
+	if updateTimestamp, err := identifier.ReadDeviceTreeUint32(logger, filepath.Join(dir, "update-timestamp")); err == nil {
+		version.UpdateTime = time.Unix(int64(updateTimestamp), 0).UTC()
+	}
+	if capabilities, err := identifier.ReadDeviceTreeUint32(logger, filepath.Join(dir, "capabilities")); err == nil {
+		version.Capabilities = capabilities
+	}
+	logger.Debug("bootloader version", slog.String("hash", version.Hash), slog.Time("date", version.Date))
+	return version, nil
+}
+
+func parseVcgencmdVersionOutput(logger *slog.Logger, output string) (*FirmwareVersion, error) {
+	logger.Debug("vcgencmd output", slog.String("output", output))
+	lines := strings.Split(output, "\n")
+	if len(lines) < 3 {
+		return nil, ErrInvalidFirmwareVersion
+	}
+	date, err := parseFirmwareDate(lines[0])
+	if err != nil {
+		logger.Debug("Failed to parse firmware date", slog.String("date", lines[0]), slog.Any("error", err))
+		return nil, ErrInvalidFirmwareVersion
+	}
+	version := &FirmwareVersion{Date: date}
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "version" {
+			continue
+		}
+		version.Hash = fields[1]
+		for _, f := range fields[2:] {
+			f = strings.Trim(f, "()")
+			switch f {
+			case "clean":
+				version.Clean = true
+			case "release", "test", "debug":
+				version.Release = f
+			default:
+				version.Variant = f
+			}
+		}
+	}
+	if version.Hash == "" {
+		return nil, ErrInvalidFirmwareVersion
+	}
+	logger.Debug("Parsed firmware version", slog.String("hash", version.Hash), slog.Time("date", version.Date))
+	return version, nil
+}
+
+func parseVcgencmdBootloaderVersionOutput(logger *slog.Logger, output string) (*BootloaderVersion, error) {
+	logger.Debug("vcgencmd output", slog.String("output", output))
+	version := &BootloaderVersion{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "version":
+			version.Hash = fields[1]
+		case "timestamp":
+			if ts, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+				version.Date = time.Unix(ts, 0).UTC()
+			}
+		case "update-time":
+			if ts, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+				version.UpdateTime = time.Unix(ts, 0).UTC()
+			}
+		case "capabilities":
+			if c, err := strconv.ParseUint(fields[1], 0, 32); err == nil {
+				version.Capabilities = uint32(c)
+			}
+		default:
+			if date, err := parseFirmwareDate(line); err == nil && version.Date.IsZero() {
+				version.Date = date
+			}
+		}
+	}
+	if version.Hash == "" || version.Date.IsZero() {
+		return nil, ErrInvalidBootloaderVersion
+	}
+	logger.Debug("Parsed bootloader version", slog.String("hash", version.Hash), slog.Time("date", version.Date))
+	return version, nil
+}
+
+func parseBootloaderConfig(output string) map[string]string {
+	config := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		config[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return config
+}
+
+func parseFirmwareDate(s string) (time.Time, error) {
+	s = strings.Join(strings.Fields(s), " ")
+	var err error
+	for _, layout := range firmwareDateLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
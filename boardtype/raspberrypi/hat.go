@@ -0,0 +1,145 @@
+package raspberrypi
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+const (
+	hatDeviceTreeDir = "/proc/device-tree/hat"
+)
+
+var (
+	ErrNoHAT      = errors.New("no HAT attached")
+	ErrInvalidHAT = errors.New("invalid HAT information")
+)
+
+type HATType struct {
+	Vendor  string
+	Product string
+}
+
+var (
+	SenseHAT   = HATType{Vendor: "Raspberry Pi", Product: "Sense HAT"}
+	PoEHAT     = HATType{Vendor: "Raspberry Pi", Product: "PoE HAT"}
+	PoEPlusHAT = HATType{Vendor: "Raspberry Pi", Product: "PoE+ HAT"}
+	AIKit      = HATType{Vendor: "Raspberry Pi", Product: "AI Kit"}
+	AIHATPlus  = HATType{Vendor: "Raspberry Pi", Product: "AI HAT+"}
+	M2HATPlus  = HATType{Vendor: "Raspberry Pi", Product: "M.2 HAT+"}
+)
+
+type hatModel struct {
+	Product string
+	Type    HATType
+}
+
+// Order matters, the first product string contained in the EEPROM product wins.
+var knownHATs = []hatModel{
+	{"Sense HAT", SenseHAT},
+	{"PoE+ HAT", PoEPlusHAT},
+	{"PoE HAT", PoEHAT},
+	{"AI Kit", AIKit},
+	{"AI HAT+", AIHATPlus},
+	{"M.2 HAT+", M2HATPlus},
+}
+
+type HAT struct {
+	Vendor         string
+	Product        string
+	ProductID      uint16
+	ProductVersion uint16
+	UUID           string
+	// HAT+ only fields, CurrentSupply is in mA
+	HATPlus       bool
+	CurrentSupply int
+	Properties    map[string]string
+}
+
+func (h HAT) GetType() (HATType, bool) {
+	for _, m := range knownHATs {
+		if strings.Contains(h.Product, m.Product) {
+			return m.Type, true
+		}
+	}
+	return HATType{}, false
+}
+
+func (h HAT) IsHATType(hatType HATType) bool {
+	t, ok := h.GetType()
+	return ok && t == hatType
+}
+
+func GetHAT(logger *slog.Logger) (*HAT, error) {
+	return readHAT(logger, hatDeviceTreeDir)
+}
+
+func readHAT(logger *slog.Logger, dir string) (*HAT, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		logger.Debug("HAT device tree node does not exist", slog.String("path", dir))
+		return nil, ErrNoHAT
+	} else if err != nil {
+		return nil, err
+	}
+	hat := &HAT{Properties: make(map[string]string)}
+	for _, e := range entries {
+		if e.IsDir() || e.Name() == "name" {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if e.Name() == "current_supply" {
+			hat.HATPlus = true
+			supply, err := identifier.ReadDeviceTreeUint32(logger, path)
+			if err != nil {
+				logger.Debug("skipping invalid HAT property", slog.String("property", e.Name()), slog.Any("error", err))
+				continue
+			}
+			hat.CurrentSupply = int(supply)
+			continue
+		}
+		value, err := identifier.ReadDeviceTreeString(logger, path)
+		if err != nil {
+			if e.Name() == "vendor" || e.Name() == "product" {
+				return nil, ErrInvalidHAT
+			}
+			continue
+		}
+		switch e.Name() {
+		case "vendor":
+			hat.Vendor = value
+		case "product":
+			hat.Product = value
+		case "uuid":
+			hat.UUID = value
+		case "product_id":
+			hat.ProductID, err = parseHATHex(value)
+		case "product_ver":
+			hat.ProductVersion, err = parseHATHex(value)
+		case "dt_blob":
+			hat.HATPlus = true
+			hat.Properties[e.Name()] = value
+		default:
+			hat.Properties[e.Name()] = value
+		}
+		if err != nil {
+			logger.Debug("skipping invalid HAT property", slog.String("property", e.Name()), slog.String("value", value), slog.Any("error", err))
+		}
+	}
+	if hat.Vendor == "" && hat.Product == "" {
+		logger.Debug("HAT device tree node is empty", slog.String("path", dir))
+		return nil, ErrNoHAT
+	}
+	logger.Debug("HAT", slog.String("vendor", hat.Vendor), slog.String("product", hat.Product), slog.Bool("hatPlus", hat.HATPlus))
+	return hat, nil
+}
+
+func parseHATHex(value string) (uint16, error) {
+	v, err := strconv.ParseUint(value, 0, 16)
+	return uint16(v), err
+}
@@ -0,0 +1,67 @@
+package raspberrypi
+
+import (
+	"encoding/binary"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+const (
+	powerDeviceTreeDir = "/proc/device-tree/chosen/power"
+	fullPowerCurrent   = 5000
+)
+
+var (
+	ErrNoPowerSupplyInfo = errors.New("power supply information not available")
+)
+
+// PowerSupply is what the Pi 5 firmware negotiated with the USB-C supply, currents are in mA.
+type PowerSupply struct {
+	MaxCurrent             int
+	USBMaxCurrentEnabled   bool
+	USBOverCurrentDetected bool
+	PowerDataObjects       []uint32
+}
+
+func (p PowerSupply) IsFullPower() bool {
+	return p.MaxCurrent >= fullPowerCurrent
+}
+
+// IsUSBCurrentLimited reports whether the USB ports are limited to 600mA total instead of 1.6A,
+// the firmware does this when the supply didn't advertise 5A and usb_max_current_enable isn't set.
+func (p PowerSupply) IsUSBCurrentLimited() bool {
+	return !p.USBMaxCurrentEnabled
+}
+
+func GetPowerSupply(logger *slog.Logger) (*PowerSupply, error) {
+	return readPowerSupply(logger, powerDeviceTreeDir)
+}
+
+func readPowerSupply(logger *slog.Logger, dir string) (*PowerSupply, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		logger.Debug("power device tree node does not exist", slog.String("path", dir))
+		return nil, ErrNoPowerSupplyInfo
+	}
+	maxCurrent, err := identifier.ReadDeviceTreeUint32(logger, filepath.Join(dir, "max_current"))
+	if err != nil {
+		return nil, ErrNoPowerSupplyInfo
+	}
+	supply := &PowerSupply{MaxCurrent: int(maxCurrent)}
+	if v, err := identifier.ReadDeviceTreeUint32(logger, filepath.Join(dir, "usb_max_current_enable")); err == nil {
+		supply.USBMaxCurrentEnabled = v != 0
+	}
+	if v, err := identifier.ReadDeviceTreeUint32(logger, filepath.Join(dir, "usb_over_current_detected")); err == nil {
+		supply.USBOverCurrentDetected = v != 0
+	}
+	if c, err := os.ReadFile(filepath.Join(dir, "usbpd_power_data_objects")); err == nil {
+		for i := 0; i+4 <= len(c); i += 4 {
+			supply.PowerDataObjects = append(supply.PowerDataObjects, binary.BigEndian.Uint32(c[i:i+4]))
+		}
+	}
+	logger.Debug("power supply", slog.Int("maxCurrent", supply.MaxCurrent), slog.Bool("usbMaxCurrentEnabled", supply.USBMaxCurrentEnabled))
+	return supply, nil
+}
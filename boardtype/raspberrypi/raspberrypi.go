@@ -72,7 +72,7 @@ func (r raspberryPiIdentifier) GetBoardType() (boardtype.SBC, error) {
 	if err == identifier.ErrCannotIdentifyBoard {
 		dtbm, err = identifier.GetDeviceTreeModel(r.logger)
 		if err == identifier.ErrCannotIdentifyBoard {
-			return nil, ErrCannotIdentifyBoard
+			return getBoardTypeFromUEFI(r.logger)
 		} else if err != nil {
 			return nil, err
 		}
@@ -80,12 +80,7 @@ func (r raspberryPiIdentifier) GetBoardType() (boardtype.SBC, error) {
 		return nil, err
 	}
 	r.logger.Debug("device tree model", slog.String("model", dtbm))
-	subModels := make([]raspberryPi, 0)
-	for _, m := range raspberryPiModels {
-		if strings.Contains(dtbm, m.Model) {
-			subModels = append(subModels, m)
-		}
-	}
+	subModels := getRaspberryPiModels(dtbm)
 	if len(subModels) == 0 {
 		return nil, ErrCannotIdentifyBoard
 	}
@@ -96,26 +91,52 @@ func (r raspberryPiIdentifier) GetBoardType() (boardtype.SBC, error) {
 	} else if err != nil {
 		return nil, err
 	}
+	board, ok := getRaspberryPiByRAM(subModels, ramMb)
+	if !ok {
+		r.logger.Debug("no matching model found, using fallback", slog.String("model", dtbm), slog.Int("ram", ramMb), slog.Int("subModels", len(subModels)), slog.Any("subModels", subModels), slog.Any("fallback", subModels[0].Fallback))
+	}
+	return board, nil
+}
+
+func getRaspberryPiModels(model string) []raspberryPi {
+	subModels := make([]raspberryPi, 0)
+	for _, m := range raspberryPiModels {
+		if strings.Contains(model, m.Model) {
+			subModels = append(subModels, m)
+		}
+	}
+	return subModels
+}
+
+// getRaspberryPiByRAM returns the model with exactly ramMb of RAM, or the fallback for the
+// first model when there isn't one.
+func getRaspberryPiByRAM(subModels []raspberryPi, ramMb int) (boardtype.SBC, bool) {
 	for _, m := range subModels {
 		if m.Memory == ramMb {
-			return m.Type, nil
+			return m.Type, true
 		}
 	}
-	r.logger.Debug("no matching model found, using fallback", slog.String("model", dtbm), slog.Int("ram", ramMb), slog.Int("subModels", len(subModels)), slog.Any("subModels", subModels), slog.Any("fallback", subModels[0].Fallback))
-	return subModels[0].Fallback, nil
+	return subModels[0].Fallback, false
 }
 
 func getInstalledRAM(logger *slog.Logger) (int, error) {
+	output, err := vcgencmd(logger, "get_config", "total_mem")
+	if err != nil {
+		return 0, err
+	}
+	return parseVcgencmdMemoryOutput(logger, output)
+}
+
+func vcgencmd(logger *slog.Logger, args ...string) (string, error) {
 	if _, err := execLookPath("vcgencmd"); err != nil {
 		logger.Debug("vcgencmd not found", slog.Any("error", err))
-		return 0, ErrVcgencmdNotFound
+		return "", ErrVcgencmdNotFound
 	}
-	out, err := exec.Command("vcgencmd", "get_config", "total_mem").Output()
+	out, err := exec.Command("vcgencmd", args...).Output()
 	if err != nil {
-		return 0, err
+		return "", err
 	}
-	output := strings.TrimSpace(string(out))
-	return parseVcgencmdMemoryOutput(logger, output)
+	return strings.TrimSpace(string(out)), nil
 }
 
 func parseVcgencmdMemoryOutput(logger *slog.Logger, output string) (int, error) {
@@ -1,11 +1,15 @@
 package raspberrypi
 
 import (
+	"encoding/binary"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/rinzlerlabs/sbcidentify/boardtype"
 	"github.com/rinzlerlabs/sbcidentify/identifier"
@@ -99,3 +103,421 @@ func TestIsBoardType(t *testing.T) {
 		})
 	}
 }
+
+func writeDeviceTreeNode(t *testing.T, props map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, value := range props {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(value), 0644); err != nil {
+			t.Fatalf("WriteFile() failed: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestReadHAT(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	_, err := readHAT(logger, filepath.Join(t.TempDir(), "hat"))
+	if err != ErrNoHAT {
+		t.Fatalf("readHAT() returned error %v, expected %v", err, ErrNoHAT)
+	}
+
+	dir := writeDeviceTreeNode(t, map[string]string{
+		"name":        "hat\x00",
+		"vendor":      "Raspberry Pi\x00",
+		"product":     "Sense HAT\x00",
+		"product_id":  "0x0001\x00",
+		"product_ver": "0x0001\x00",
+		"uuid":        "3e4b7c8d-0d64-4ef1-8f2b-b6f1a3d36e2a\x00",
+	})
+	hat, err := readHAT(logger, dir)
+	if err != nil {
+		t.Fatalf("readHAT() failed: %v", err)
+	}
+	if hat.Vendor != "Raspberry Pi" || hat.Product != "Sense HAT" || hat.ProductID != 1 || hat.ProductVersion != 1 {
+		t.Fatalf("readHAT() returned %+v", hat)
+	}
+	if hat.HATPlus {
+		t.Fatalf("readHAT() reported a HAT+ for a HAT")
+	}
+	if !hat.IsHATType(SenseHAT) {
+		t.Fatalf("IsHATType() returned false, expected true")
+	}
+
+	dir = writeDeviceTreeNode(t, map[string]string{
+		"vendor":         "Raspberry Pi Ltd\x00",
+		"product":        "Raspberry Pi M.2 HAT+\x00",
+		"product_id":     "0x0004\x00",
+		"product_ver":    "0x0002\x00",
+		"current_supply": "\x00\x00\x0b\xb8",
+	})
+	hat, err = readHAT(logger, dir)
+	if err != nil {
+		t.Fatalf("readHAT() failed: %v", err)
+	}
+	if !hat.HATPlus || hat.CurrentSupply != 3000 {
+		t.Fatalf("readHAT() returned %+v, expected a HAT+ supplying 3000mA", hat)
+	}
+	if hatType, ok := hat.GetType(); !ok || hatType != M2HATPlus {
+		t.Fatalf("GetType() returned %v, expected %v", hatType, M2HATPlus)
+	}
+
+	dir = writeDeviceTreeNode(t, map[string]string{
+		"vendor":         "Raspberry Pi\x00",
+		"product":        "Raspberry Pi AI HAT+\x00",
+		"product_id":     "foo\x00",
+		"product_ver":    "0x0001\x00",
+		"current_supply": "3000\x00",
+	})
+	hat, err = readHAT(logger, dir)
+	if err != nil {
+		t.Fatalf("readHAT() failed: %v", err)
+	}
+	if !hat.HATPlus || hat.ProductID != 0 || hat.ProductVersion != 1 || hat.CurrentSupply != 0 {
+		t.Fatalf("readHAT() returned %+v, expected the invalid properties to be skipped", hat)
+	}
+}
+
+func TestParseVcgencmdVersionOutput(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	tests := []struct {
+		input   string
+		date    time.Time
+		hash    string
+		variant string
+		err     error
+	}{
+		{"Mar 17 2023 10:52:42 \nCopyright (c) 2012 Broadcom\nversion 82f3750a65fadae9a38077e3c2e217ad158c8d54 (clean) (release) (start)", time.Date(2023, 3, 17, 10, 52, 42, 0, time.UTC), "82f3750a65fadae9a38077e3c2e217ad158c8d54", "start", nil},
+		{"Nov  1 2024 10:52:42\nCopyright (c) 2012 Broadcom\nversion 82f3750a (clean) (release) (start4)", time.Date(2024, 11, 1, 10, 52, 42, 0, time.UTC), "82f3750a", "start4", nil},
+		{"2024/11/12 16:10:44 \nCopyright (c) 2012 Broadcom\nversion 4b019946 (release) (embedded)", time.Date(2024, 11, 12, 16, 10, 44, 0, time.UTC), "4b019946", "embedded", nil},
+		{"version 4b019946 (release) (embedded)", time.Time{}, "", "", ErrInvalidFirmwareVersion},
+		{"foo\nbar\nbaz", time.Time{}, "", "", ErrInvalidFirmwareVersion},
+	}
+	for _, test := range tests {
+		t.Run(test.hash, func(t *testing.T) {
+			version, err := parseVcgencmdVersionOutput(logger, test.input)
+			if err != test.err {
+				t.Fatalf("parseVcgencmdVersionOutput() returned error %v, expected %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+			if !version.Date.Equal(test.date) || version.Hash != test.hash || version.Variant != test.variant || version.Release != "release" {
+				t.Fatalf("parseVcgencmdVersionOutput() returned %+v", version)
+			}
+		})
+	}
+
+	a := FirmwareVersion{Date: time.Date(2023, 3, 17, 0, 0, 0, 0, time.UTC)}
+	b := FirmwareVersion{Date: time.Date(2024, 11, 12, 0, 0, 0, 0, time.UTC)}
+	if a.Compare(b) >= 0 || b.Compare(a) <= 0 || a.Compare(a) != 0 {
+		t.Fatalf("Compare() ordered %v and %v incorrectly", a.Date, b.Date)
+	}
+	if !b.IsAtLeast(a.Date) || a.IsAtLeast(b.Date) {
+		t.Fatalf("IsAtLeast() ordered %v and %v incorrectly", a.Date, b.Date)
+	}
+}
+
+func TestParseBootloaderVersion(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	output := "2023/01/11 17:40:52\nversion 8ba17717fbcedd4c3b6d4bce7e50c7af4155cba9 (release)\ntimestamp 1673458852\nupdate-time 1674039025\ncapabilities 0x0000007f"
+	version, err := parseVcgencmdBootloaderVersionOutput(logger, output)
+	if err != nil {
+		t.Fatalf("parseVcgencmdBootloaderVersionOutput() failed: %v", err)
+	}
+	if version.Hash != "8ba17717fbcedd4c3b6d4bce7e50c7af4155cba9" || version.Date.Unix() != 1673458852 || version.UpdateTime.Unix() != 1674039025 || version.Capabilities != 0x7f {
+		t.Fatalf("parseVcgencmdBootloaderVersionOutput() returned %+v", version)
+	}
+	if _, err := parseVcgencmdBootloaderVersionOutput(logger, "capabilities 0x0000007f"); err != ErrInvalidBootloaderVersion {
+		t.Fatalf("parseVcgencmdBootloaderVersionOutput() returned error %v, expected %v", err, ErrInvalidBootloaderVersion)
+	}
+
+	u32 := func(v uint32) string {
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, v)
+		return string(b)
+	}
+	dir := writeDeviceTreeNode(t, map[string]string{
+		"version":          "8ba17717fbcedd4c3b6d4bce7e50c7af4155cba9\x00",
+		"build-timestamp":  u32(1673458852),
+		"update-timestamp": u32(1674039025),
+		"capabilities":     u32(0x7f),
+	})
+	version, err = readBootloaderVersion(logger, dir)
+	if err != nil {
+		t.Fatalf("readBootloaderVersion() failed: %v", err)
+	}
+	if version.Hash != "8ba17717fbcedd4c3b6d4bce7e50c7af4155cba9" || version.Date.Unix() != 1673458852 || version.UpdateTime.Unix() != 1674039025 || version.Capabilities != 0x7f {
+		t.Fatalf("readBootloaderVersion() returned %+v", version)
+	}
+
+	config := parseBootloaderConfig("[all]\nBOOT_UART=0\n# comment\nBOOT_ORDER=0xf416\n\nPCIE_PROBE = 1")
+	if len(config) != 3 || config["BOOT_ORDER"] != "0xf416" || config["PCIE_PROBE"] != "1" {
+		t.Fatalf("parseBootloaderConfig() returned %v", config)
+	}
+}
+
+func TestParseVcgencmdThrottledOutput(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	tests := []struct {
+		input    string
+		current  ThrottleFlags
+		occurred ThrottleFlags
+		err      error
+	}{
+		{"throttled=0x0", ThrottleFlags{}, ThrottleFlags{}, nil},
+		{"throttled=0x50000", ThrottleFlags{}, ThrottleFlags{UnderVoltage: true, Throttled: true}, nil},
+		{"throttled=0x50005", ThrottleFlags{UnderVoltage: true, Throttled: true}, ThrottleFlags{UnderVoltage: true, Throttled: true}, nil},
+		{"throttled=0xa000a", ThrottleFlags{FrequencyCapped: true, SoftTempLimit: true}, ThrottleFlags{FrequencyCapped: true, SoftTempLimit: true}, nil},
+		{"throttled=", ThrottleFlags{}, ThrottleFlags{}, ErrInvalidThrottledStatus},
+		{"throttled=foo", ThrottleFlags{}, ThrottleFlags{}, ErrInvalidThrottledStatus},
+		{"total_mem=1024", ThrottleFlags{}, ThrottleFlags{}, ErrInvalidThrottledStatus},
+		{"", ThrottleFlags{}, ThrottleFlags{}, ErrInvalidThrottledStatus},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			status, err := parseVcgencmdThrottledOutput(logger, test.input)
+			if err != test.err {
+				t.Fatalf("parseVcgencmdThrottledOutput() returned error %v, expected %v", err, test.err)
+			}
+			if status.Current != test.current || status.Occurred != test.occurred {
+				t.Fatalf("parseVcgencmdThrottledOutput() returned %v, expected current %v and occurred %v", status, test.current, test.occurred)
+			}
+		})
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	dir := writeDeviceTreeNode(t, map[string]string{
+		"config.txt": `# comment
+dtparam=audio=on
+camera_auto_detect=1
+arm_64bit=1
+
+[pi4]
+arm_boost=1
+dtoverlay=vc4-kms-v3d-pi4
+
+[pi5]
+dtoverlay=vc4-kms-v3d
+dtparam=pciex1
+include extra.txt
+
+[cm4]
+otg_mode=1
+
+[board-type=0x17]
+usb_max_current_enable=1
+
+[all]
+[HDMI:0]
+hdmi_group=2
+
+[EDID=VSC-TD2220]
+hdmi_group=1
+
+[none]
+arm_64bit=0
+
+[all]
+initramfs initrd.img followkernel
+`,
+		"extra.txt": "dtparam=pciex1_gen=3\narm_64bit=1\n",
+	})
+	path := filepath.Join(dir, "config.txt")
+
+	tests := []struct {
+		model    string
+		settings map[string]string
+		dt       []ConfigDirective
+	}{
+		{
+			"Raspberry Pi 5 Model B Rev 1.0",
+			map[string]string{"camera_auto_detect": "1", "arm_64bit": "1", "usb_max_current_enable": "1", "hdmi_group": "2", "initramfs": "initrd.img followkernel"},
+			[]ConfigDirective{{"dtparam", "audio=on"}, {"dtoverlay", "vc4-kms-v3d"}, {"dtparam", "pciex1"}, {"dtparam", "pciex1_gen=3"}},
+		},
+		{
+			"Raspberry Pi Compute Module 4 Rev 1.0",
+			map[string]string{"camera_auto_detect": "1", "arm_64bit": "1", "arm_boost": "1", "otg_mode": "1", "hdmi_group": "2", "initramfs": "initrd.img followkernel"},
+			[]ConfigDirective{{"dtparam", "audio=on"}, {"dtoverlay", "vc4-kms-v3d-pi4"}},
+		},
+		{
+			"Raspberry Pi Compute Module 4S Rev 1.0",
+			map[string]string{"camera_auto_detect": "1", "arm_64bit": "1", "arm_boost": "1", "hdmi_group": "2", "initramfs": "initrd.img followkernel"},
+			[]ConfigDirective{{"dtparam", "audio=on"}, {"dtoverlay", "vc4-kms-v3d-pi4"}},
+		},
+		{
+			"Raspberry Pi 3 Model B Plus Rev 1.3",
+			map[string]string{"camera_auto_detect": "1", "arm_64bit": "1", "hdmi_group": "2", "initramfs": "initrd.img followkernel"},
+			[]ConfigDirective{{"dtparam", "audio=on"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.model, func(t *testing.T) {
+			config, err := ParseConfig(logger, path, test.model)
+			if err != nil {
+				t.Fatalf("ParseConfig() failed: %v", err)
+			}
+			if !reflect.DeepEqual(config.Settings, test.settings) {
+				t.Fatalf("ParseConfig() returned settings %v, expected %v", config.Settings, test.settings)
+			}
+			if !reflect.DeepEqual(config.DeviceTree, test.dt) {
+				t.Fatalf("ParseConfig() returned device tree directives %v, expected %v", config.DeviceTree, test.dt)
+			}
+		})
+	}
+
+	config, _ := ParseConfig(logger, path, "Raspberry Pi 5 Model B Rev 1.0")
+	if overlays := config.Overlays(); !reflect.DeepEqual(overlays, []string{"vc4-kms-v3d"}) {
+		t.Fatalf("Overlays() returned %v", overlays)
+	}
+	if params := config.Params(); !reflect.DeepEqual(params, []string{"audio=on", "pciex1", "pciex1_gen=3"}) {
+		t.Fatalf("Params() returned %v", params)
+	}
+
+	loop := writeDeviceTreeNode(t, map[string]string{"config.txt": "include config.txt\n"})
+	if _, err := ParseConfig(logger, filepath.Join(loop, "config.txt"), "Raspberry Pi 5 Model B Rev 1.0"); err != ErrConfigIncludeTooDeep {
+		t.Fatalf("ParseConfig() returned error %v, expected %v", err, ErrConfigIncludeTooDeep)
+	}
+}
+
+func TestReadPowerSupply(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	_, err := readPowerSupply(logger, filepath.Join(t.TempDir(), "power"))
+	if err != ErrNoPowerSupplyInfo {
+		t.Fatalf("readPowerSupply() returned error %v, expected %v", err, ErrNoPowerSupplyInfo)
+	}
+
+	u32 := func(v ...uint32) string {
+		b := make([]byte, 0)
+		for _, x := range v {
+			b = binary.BigEndian.AppendUint32(b, x)
+		}
+		return string(b)
+	}
+	tests := []struct {
+		name      string
+		props     map[string]string
+		fullPower bool
+		limited   bool
+	}{
+		{"5A", map[string]string{"max_current": u32(5000), "usb_max_current_enable": u32(1), "usbpd_power_data_objects": u32(0x0801912c, 0x0002d12c)}, true, false},
+		{"3A", map[string]string{"max_current": u32(3000), "usb_max_current_enable": u32(0)}, false, true},
+		{"3A forced", map[string]string{"max_current": u32(3000), "usb_max_current_enable": u32(1)}, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			supply, err := readPowerSupply(logger, writeDeviceTreeNode(t, test.props))
+			if err != nil {
+				t.Fatalf("readPowerSupply() failed: %v", err)
+			}
+			if supply.IsFullPower() != test.fullPower || supply.IsUSBCurrentLimited() != test.limited {
+				t.Fatalf("readPowerSupply() returned %+v", supply)
+			}
+		})
+	}
+}
+
+// stubUEFI replaces the UEFI sources, an empty vendor, 0 or an empty table ID means it's missing.
+func stubUEFI(t *testing.T, vendor string, product string, dmiMB int, memMB int, oemTableID string) {
+	t.Helper()
+	t.Cleanup(func() {
+		getDMIInfo = identifier.GetDMIInfo
+		getDMIMemory = identifier.GetDMIMemory
+		resolveRAMVariant = identifier.ResolveRAMVariant
+		getACPITableHeader = identifier.GetACPITableHeader
+	})
+	getDMIInfo = func(*slog.Logger) (*identifier.DMIInfo, error) {
+		if vendor == "" {
+			return nil, identifier.ErrDMINotAvailable
+		}
+		return &identifier.DMIInfo{SysVendor: vendor, ProductName: product}, nil
+	}
+	getDMIMemory = func(*slog.Logger) (int, error) {
+		if dmiMB == 0 {
+			return 0, identifier.ErrDMIMemoryNotReported
+		}
+		return dmiMB, nil
+	}
+	resolveRAMVariant = func(_ *slog.Logger, base boardtype.SBC, variants []boardtype.SBC) (boardtype.SBC, bool) {
+		if variant, ok := identifier.MatchRAMVariant(memMB, variants); memMB > 0 && ok {
+			return variant, true
+		}
+		return base, false
+	}
+	getACPITableHeader = func(_ *slog.Logger, signature string) (*identifier.ACPITableHeader, error) {
+		if oemTableID == "" || signature != "DSDT" {
+			return nil, identifier.ErrACPITableNotFound
+		}
+		return &identifier.ACPITableHeader{Signature: signature, OEMID: raspberryPiACPIOEMID, OEMTableID: oemTableID}, nil
+	}
+}
+
+func TestGetBoardTypeFromUEFI(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	tests := []struct {
+		name       string
+		vendor     string
+		product    string
+		dmiMB      int
+		memMB      int
+		oemTableID string
+		expected   boardtype.SBC
+	}{
+		{"SMBIOS memory", "Raspberry Pi Foundation", "Raspberry Pi 4 Model B", 8192, 2951, "RPI4", boardtype.RaspberryPi4B8GB},
+		{"SMBIOS memory without a match", "Raspberry Pi Foundation", "Raspberry Pi 4 Model B", 3072, 2951, "RPI4", boardtype.RaspberryPi4B},
+		{"MemTotal", "Raspberry Pi Ltd", "Raspberry Pi 5 Model B", 0, 8052, "RPI5", boardtype.RaspberryPi5B8GB},
+		{"MemTotal limited to 3GB", "Raspberry Pi Foundation", "Raspberry Pi 4 Model B", 0, 2951, "RPI4", boardtype.RaspberryPi4B4GB},
+		{"no RAM", "Raspberry Pi Foundation", "Raspberry Pi Compute Module 4", 0, 0, "RPI4", boardtype.RaspberryPi4B},
+		{"unknown product", "Raspberry Pi Foundation", "Raspberry Pi 9", 8192, 0, "RPI4", boardtype.RaspberryPi4},
+		{"other vendor", "Contoso", "Raspberry Pi 4 Model B", 8192, 0, "RPI5", boardtype.RaspberryPi5},
+		{"DSDT", "", "", 0, 0, "RPI4", boardtype.RaspberryPi4},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stubUEFI(t, test.vendor, test.product, test.dmiMB, test.memMB, test.oemTableID)
+			board, err := getBoardTypeFromUEFI(logger)
+			if err != nil {
+				t.Fatalf("getBoardTypeFromUEFI() failed: %v", err)
+			}
+			if board != test.expected {
+				t.Fatalf("getBoardTypeFromUEFI() returned %v, expected %v", board.GetPrettyName(), test.expected.GetPrettyName())
+			}
+		})
+	}
+	stubUEFI(t, "Contoso", "Edge 100", 8192, 0, "")
+	_, err := getBoardTypeFromUEFI(logger)
+	if err != ErrCannotIdentifyBoard {
+		t.Fatalf("getBoardTypeFromUEFI() returned error %v, expected %v", err, ErrCannotIdentifyBoard)
+	}
+}
+
+func TestGetBoardTypeByACPI(t *testing.T) {
+	tests := []struct {
+		header   identifier.ACPITableHeader
+		expected boardtype.SBC
+	}{
+		{identifier.ACPITableHeader{Signature: "DSDT", OEMID: "RPIFDN", OEMTableID: "RPI4"}, boardtype.RaspberryPi4},
+		{identifier.ACPITableHeader{Signature: "DSDT", OEMID: "RPIFDN", OEMTableID: "RPI5"}, boardtype.RaspberryPi5},
+		{identifier.ACPITableHeader{Signature: "DSDT", OEMID: "RPIFDN", OEMTableID: "RPI"}, boardtype.RaspberryPi},
+	}
+	for _, test := range tests {
+		board, err := getBoardTypeByACPI(&test.header)
+		if err != nil {
+			t.Fatalf("getBoardTypeByACPI() failed: %v", err)
+		}
+		if board != test.expected {
+			t.Fatalf("getBoardTypeByACPI() returned %v, expected %v", board, test.expected)
+		}
+	}
+	if _, err := getBoardTypeByACPI(&identifier.ACPITableHeader{Signature: "DSDT", OEMID: "INTEL", OEMTableID: "EDK2"}); err != ErrCannotIdentifyBoard {
+		t.Fatalf("getBoardTypeByACPI() returned error %v, expected %v", err, ErrCannotIdentifyBoard)
+	}
+}
@@ -0,0 +1,102 @@
+package raspberrypi
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidThrottledStatus = errors.New("invalid throttled status")
+)
+
+const (
+	throttledUnderVoltage    = 1 << 0
+	throttledFrequencyCapped = 1 << 1
+	throttledThrottled       = 1 << 2
+	throttledSoftTempLimit   = 1 << 3
+	throttledOccurredShift   = 16
+)
+
+type ThrottleFlags struct {
+	UnderVoltage    bool
+	FrequencyCapped bool
+	Throttled       bool
+	SoftTempLimit   bool
+}
+
+func (f ThrottleFlags) Any() bool {
+	return f.UnderVoltage || f.FrequencyCapped || f.Throttled || f.SoftTempLimit
+}
+
+func (f ThrottleFlags) String() string {
+	flags := make([]string, 0)
+	if f.UnderVoltage {
+		flags = append(flags, "under-voltage")
+	}
+	if f.FrequencyCapped {
+		flags = append(flags, "frequency capped")
+	}
+	if f.Throttled {
+		flags = append(flags, "throttled")
+	}
+	if f.SoftTempLimit {
+		flags = append(flags, "soft temperature limit")
+	}
+	if len(flags) == 0 {
+		return "none"
+	}
+	return strings.Join(flags, ", ")
+}
+
+// ThrottledStatus is the decoded get_throttled bitmask, Current is what is happening now and
+// Occurred is sticky since boot.
+type ThrottledStatus struct {
+	Raw      uint32
+	Current  ThrottleFlags
+	Occurred ThrottleFlags
+}
+
+func (s ThrottledStatus) String() string {
+	return "current: " + s.Current.String() + "; since boot: " + s.Occurred.String()
+}
+
+func GetThrottledStatus(logger *slog.Logger) (ThrottledStatus, error) {
+	output, err := vcgencmd(logger, "get_throttled")
+	if err != nil {
+		return ThrottledStatus{}, err
+	}
+	return parseVcgencmdThrottledOutput(logger, output)
+}
+
+func parseVcgencmdThrottledOutput(logger *slog.Logger, output string) (ThrottledStatus, error) {
+	logger.Debug("vcgencmd output", slog.String("output", output))
+	parts := strings.Split(output, "=")
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) != "throttled" {
+		return ThrottledStatus{}, ErrInvalidThrottledStatus
+	}
+	raw, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 0, 32)
+	if err != nil {
+		logger.Debug("Failed to parse throttled status", slog.String("output", output), slog.Any("error", err))
+		return ThrottledStatus{}, ErrInvalidThrottledStatus
+	}
+	return decodeThrottled(uint32(raw)), nil
+}
+
+func decodeThrottled(raw uint32) ThrottledStatus {
+	return ThrottledStatus{
+		Raw:      raw,
+		Current:  decodeThrottleFlags(raw),
+		Occurred: decodeThrottleFlags(raw >> throttledOccurredShift),
+	}
+}
+
+func decodeThrottleFlags(bits uint32) ThrottleFlags {
+	return ThrottleFlags{
+		UnderVoltage:    bits&throttledUnderVoltage != 0,
+		FrequencyCapped: bits&throttledFrequencyCapped != 0,
+		Throttled:       bits&throttledThrottled != 0,
+		SoftTempLimit:   bits&throttledSoftTempLimit != 0,
+	}
+}
@@ -0,0 +1,93 @@
+package raspberrypi
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+const raspberryPiACPIOEMID = "RPIFDN"
+
+var (
+	getDMIInfo         = identifier.GetDMIInfo
+	getDMIMemory       = identifier.GetDMIMemory
+	resolveRAMVariant  = identifier.ResolveRAMVariant
+	getACPITableHeader = identifier.GetACPITableHeader
+)
+
+var raspberryPiSMBIOSVendors = []string{
+	"Raspberry Pi Foundation",
+	"Raspberry Pi Ltd",
+	"Raspberry Pi Trading Ltd",
+}
+
+type raspberryPiACPI struct {
+	OEMTableID string
+	Type       boardtype.SBC
+}
+
+// The ACPI tables only say which generation the firmware was built for, not the model.
+var raspberryPiACPITables = []raspberryPiACPI{
+	{"RPI3", boardtype.RaspberryPi3},
+	{"RPI4", boardtype.RaspberryPi4},
+	{"RPI5", boardtype.RaspberryPi5},
+}
+
+// getBoardTypeFromUEFI identifies a Pi booted through the UEFI firmware, which has no device
+// tree. SMBIOS has the same model string as the device tree, failing that the DSDT OEM ID
+// gives the generation.
+func getBoardTypeFromUEFI(logger *slog.Logger) (boardtype.SBC, error) {
+	if info, err := getDMIInfo(logger); err == nil && isRaspberryPiVendor(info) {
+		if board, err := getBoardTypeFromSMBIOS(logger, info.ProductName); err == nil {
+			return board, nil
+		}
+		logger.Debug("SMBIOS product does not match any boards", slog.String("product", info.ProductName))
+	}
+	header, err := getACPITableHeader(logger, "DSDT")
+	if err != nil {
+		return nil, ErrCannotIdentifyBoard
+	}
+	return getBoardTypeByACPI(header)
+}
+
+// getBoardTypeFromSMBIOS prefers the SMBIOS memory devices for the RAM, MemTotal is a fallback
+// because the UEFI firmware can limit the RAM to 3GB.
+func getBoardTypeFromSMBIOS(logger *slog.Logger, product string) (boardtype.SBC, error) {
+	subModels := getRaspberryPiModels(product)
+	if len(subModels) == 0 {
+		return nil, ErrCannotIdentifyBoard
+	}
+	if ramMb, err := getDMIMemory(logger); err == nil {
+		board, _ := getRaspberryPiByRAM(subModels, ramMb)
+		return board, nil
+	}
+	variants := make([]boardtype.SBC, 0, len(subModels))
+	for _, m := range subModels {
+		variants = append(variants, m.Type)
+	}
+	board, _ := resolveRAMVariant(logger, subModels[0].Fallback, variants)
+	return board, nil
+}
+
+func getBoardTypeByACPI(header *identifier.ACPITableHeader) (boardtype.SBC, error) {
+	if header.OEMID != raspberryPiACPIOEMID {
+		return nil, ErrCannotIdentifyBoard
+	}
+	for _, t := range raspberryPiACPITables {
+		if strings.HasPrefix(header.OEMTableID, t.OEMTableID) {
+			return t.Type, nil
+		}
+	}
+	return boardtype.RaspberryPi, nil
+}
+
+func isRaspberryPiVendor(info *identifier.DMIInfo) bool {
+	for _, v := range raspberryPiSMBIOSVendors {
+		if strings.EqualFold(info.SysVendor, v) || strings.EqualFold(info.BoardVendor, v) {
+			return true
+		}
+	}
+	return false
+}
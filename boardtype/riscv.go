@@ -0,0 +1,24 @@
+package boardtype
+
+var (
+	StarFive               = BoardType{Manufacturer: "StarFive", Model: "", SubModel: "", RAM: 0}
+	StarFiveVisionFive     = BoardType{Manufacturer: "StarFive", Model: "VisionFive", SubModel: "", RAM: 0, BaseModel: &StarFive}
+	StarFiveVisionFive2    = BoardType{Manufacturer: "StarFive", Model: "VisionFive", SubModel: "2", RAM: 0, BaseModel: &StarFiveVisionFive}
+	StarFiveVisionFive22GB = BoardType{Manufacturer: "StarFive", Model: "VisionFive", SubModel: "2", RAM: 2048, BaseModel: &StarFiveVisionFive2}
+	StarFiveVisionFive24GB = BoardType{Manufacturer: "StarFive", Model: "VisionFive", SubModel: "2", RAM: 4096, BaseModel: &StarFiveVisionFive2}
+	StarFiveVisionFive28GB = BoardType{Manufacturer: "StarFive", Model: "VisionFive", SubModel: "2", RAM: 8192, BaseModel: &StarFiveVisionFive2}
+	MilkV                  = BoardType{Manufacturer: "Milk-V", Model: "", SubModel: "", RAM: 0}
+	MilkVDuo               = BoardType{Manufacturer: "Milk-V", Model: "Duo", SubModel: "", RAM: 64, BaseModel: &MilkV}
+	MilkVDuo256M           = BoardType{Manufacturer: "Milk-V", Model: "Duo", SubModel: "256M", RAM: 256, BaseModel: &MilkVDuo}
+	MilkVDuoS              = BoardType{Manufacturer: "Milk-V", Model: "Duo", SubModel: "S", RAM: 512, BaseModel: &MilkVDuo}
+	MilkVMars              = BoardType{Manufacturer: "Milk-V", Model: "Mars", SubModel: "", RAM: 0, BaseModel: &MilkV}
+	MilkVPioneer           = BoardType{Manufacturer: "Milk-V", Model: "Pioneer", SubModel: "", RAM: 0, BaseModel: &MilkV}
+	Sipeed                 = BoardType{Manufacturer: "Sipeed", Model: "", SubModel: "", RAM: 0}
+	SipeedLichee           = BoardType{Manufacturer: "Sipeed", Model: "Lichee", SubModel: "", RAM: 0, BaseModel: &Sipeed}
+	SipeedLicheePi4A       = BoardType{Manufacturer: "Sipeed", Model: "Lichee", SubModel: "Pi 4A", RAM: 0, BaseModel: &SipeedLichee}
+	SipeedLicheeRV         = BoardType{Manufacturer: "Sipeed", Model: "Lichee", SubModel: "RV", RAM: 0, BaseModel: &SipeedLichee}
+	SipeedLicheeRVDock     = BoardType{Manufacturer: "Sipeed", Model: "Lichee", SubModel: "RV Dock", RAM: 0, BaseModel: &SipeedLicheeRV}
+	BeagleV                = BoardType{Manufacturer: "BeagleBoard.org", Model: "BeagleV", SubModel: "", RAM: 0, BaseModel: &BeagleBoard}
+	BeagleVAhead           = BoardType{Manufacturer: "BeagleBoard.org", Model: "BeagleV", SubModel: "Ahead", RAM: 4096, BaseModel: &BeagleV}
+	BeagleVFire            = BoardType{Manufacturer: "BeagleBoard.org", Model: "BeagleV", SubModel: "Fire", RAM: 2048, BaseModel: &BeagleV}
+)
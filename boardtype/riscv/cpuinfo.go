@@ -0,0 +1,139 @@
+package riscv
+
+import (
+	"bufio"
+	"errors"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	procCpuinfoFile = "/proc/cpuinfo"
+)
+
+var (
+	ErrNotRISCV = errors.New("cpuinfo does not describe a RISC-V CPU")
+)
+
+// CPUInfo is the RISC-V part of /proc/cpuinfo, the ISA only lists the extensions that every
+// hart supports so it is safe to build for.
+type CPUInfo struct {
+	Harts     int
+	ISA       ISA
+	MMU       string
+	UArch     string
+	MVendorID string
+	MArchID   string
+	MImpID    string
+}
+
+type ISA struct {
+	Base       string
+	Extensions []string
+}
+
+func (i ISA) HasExtension(ext string) bool {
+	return slices.Contains(i.Extensions, strings.ToLower(ext))
+}
+
+func (i ISA) String() string {
+	var single, multi strings.Builder
+	for _, e := range i.Extensions {
+		if len(e) == 1 {
+			single.WriteString(e)
+		} else {
+			multi.WriteString("_" + e)
+		}
+	}
+	return i.Base + single.String() + multi.String()
+}
+
+func GetCPUInfo(logger *slog.Logger) (*CPUInfo, error) {
+	return readCPUInfo(logger, procCpuinfoFile)
+}
+
+func readCPUInfo(logger *slog.Logger, path string) (*CPUInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		logger.Debug("cannot read cpuinfo", slog.Any("error", err))
+		return nil, err
+	}
+	defer f.Close()
+	ret := &CPUInfo{}
+	var isa *ISA
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "hart":
+			ret.Harts++
+		case "isa":
+			hart := ParseISA(value)
+			if isa == nil {
+				isa = &hart
+			} else {
+				isa.Extensions = slices.DeleteFunc(isa.Extensions, func(e string) bool {
+					return !hart.HasExtension(e)
+				})
+			}
+		case "mmu":
+			ret.MMU = value
+		case "uarch":
+			ret.UArch = value
+		case "mvendorid":
+			ret.MVendorID = value
+		case "marchid":
+			ret.MArchID = value
+		case "mimpid":
+			ret.MImpID = value
+		}
+	}
+	if isa == nil {
+		logger.Debug("cpuinfo has no isa field")
+		return nil, ErrNotRISCV
+	}
+	ret.ISA = *isa
+	logger.Debug("RISC-V cpuinfo", slog.Int("harts", ret.Harts), slog.String("isa", ret.ISA.String()), slog.String("mmu", ret.MMU), slog.String("uarch", ret.UArch))
+	return ret, nil
+}
+
+// ParseISA splits an ISA string such as rv64imafdc_zicsr_zifencei into the base and its
+// extensions, G is expanded to IMAFD plus Zicsr and Zifencei.
+func ParseISA(s string) ISA {
+	s = strings.ToLower(strings.TrimSpace(s))
+	ret := ISA{Extensions: make([]string, 0)}
+	add := func(e string) {
+		if e != "" && !slices.Contains(ret.Extensions, e) {
+			ret.Extensions = append(ret.Extensions, e)
+		}
+	}
+	parts := strings.Split(s, "_")
+	single := parts[0]
+	if strings.HasPrefix(single, "rv") {
+		i := 2
+		for i < len(single) && single[i] >= '0' && single[i] <= '9' {
+			i++
+		}
+		ret.Base = single[:i]
+		single = single[i:]
+	}
+	for _, c := range single {
+		if c == 'g' {
+			for _, e := range []string{"i", "m", "a", "f", "d", "zicsr", "zifencei"} {
+				add(e)
+			}
+			continue
+		}
+		add(string(c))
+	}
+	for _, p := range parts[1:] {
+		add(p)
+	}
+	return ret
+}
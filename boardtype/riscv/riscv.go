@@ -0,0 +1,126 @@
+package riscv
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+func init() {
+	identifier.RegisterBoardIdentifier(NewRISCVIdentifier)
+}
+
+var (
+	ErrCannotIdentifyBoard = errors.New("cannot identify RISC-V board")
+)
+
+type riscvBoard struct {
+	Compatible string
+	Model      string
+	Type       boardtype.SBC
+	Variants   []boardtype.SBC
+}
+
+// Models are matched by prefix so the longer names come first, Milk-V Duo is a prefix of
+// Milk-V DuoS.
+var riscvBoards = []riscvBoard{
+	{"starfive,visionfive-2-v1.3b", "StarFive VisionFive 2", boardtype.StarFiveVisionFive2, []boardtype.SBC{boardtype.StarFiveVisionFive22GB, boardtype.StarFiveVisionFive24GB, boardtype.StarFiveVisionFive28GB}},
+	{"starfive,visionfive-2-v1.2a", "StarFive VisionFive 2", boardtype.StarFiveVisionFive2, []boardtype.SBC{boardtype.StarFiveVisionFive22GB, boardtype.StarFiveVisionFive24GB, boardtype.StarFiveVisionFive28GB}},
+	{"starfive,visionfive-v1", "StarFive VisionFive", boardtype.StarFiveVisionFive, nil},
+	{"milkv,duo256m", "Milk-V Duo256M", boardtype.MilkVDuo256M, nil},
+	{"milkv,duos", "Milk-V DuoS", boardtype.MilkVDuoS, nil},
+	{"milkv,duo", "Milk-V Duo", boardtype.MilkVDuo, nil},
+	{"milkv,mars", "Milk-V Mars", boardtype.MilkVMars, nil},
+	{"milkv,pioneer", "Milk-V Pioneer", boardtype.MilkVPioneer, nil},
+	{"sipeed,lichee-pi-4a", "Sipeed Lichee Pi 4A", boardtype.SipeedLicheePi4A, nil},
+	{"sipeed,lichee-rv-dock", "Sipeed Lichee RV Dock", boardtype.SipeedLicheeRVDock, nil},
+	{"sipeed,lichee-rv", "Sipeed Lichee RV", boardtype.SipeedLicheeRV, nil},
+	{"beagle,beaglev-ahead", "BeagleV Ahead", boardtype.BeagleVAhead, nil},
+	{"beagle,beaglev-fire", "BeagleV Fire", boardtype.BeagleVFire, nil},
+}
+
+// riscvSoCs is only a hint for when cpuinfo can't be read, the isa field is what says the CPU
+// is RISC-V.
+var riscvSoCs = []string{
+	"starfive,",
+	"sophgo,",
+	"thead,",
+	"sifive,",
+	"spacemit,",
+	"canaan,",
+	"allwinner,sun20i-d1",
+	"microchip,mpfs",
+}
+
+type riscvIdentifier struct {
+	logger *slog.Logger
+}
+
+func NewRISCVIdentifier(logger *slog.Logger) identifier.BoardIdentifier {
+	logger.Debug("initializing RISC-V identifier")
+	newLogger := logger.With(slog.String("source", "RISC-V"))
+	return riscvIdentifier{
+		logger: newLogger,
+	}
+}
+
+func (r riscvIdentifier) Name() string {
+	return "RISC-V Identifier"
+}
+
+func (r riscvIdentifier) GetBoardType() (boardtype.SBC, error) {
+	compatible, err := identifier.GetDeviceTreeCompatible(r.logger)
+	if err != nil {
+		return nil, ErrCannotIdentifyBoard
+	}
+	model, _ := identifier.GetDeviceTreeModel(r.logger)
+	board, ok := getRISCVBoard(compatible, model, isRISCV(r.logger, procCpuinfoFile, compatible))
+	if !ok {
+		r.logger.Debug("unknown board", slog.String("model", model))
+		return nil, ErrCannotIdentifyBoard
+	}
+	variant, _ := identifier.ResolveRAMVariant(r.logger, board.Type, board.Variants)
+	return variant, nil
+}
+
+// Without a board compatible the model has to start with the board name, case-insensitively,
+// which is why Milk-V Duo256M and DuoS are listed before Duo. The riscv flag comes from cpuinfo
+// or the compatible list, so other CPUs never reach the model match.
+func getRISCVBoard(compatible []string, model string, riscv bool) (riscvBoard, bool) {
+	for _, c := range compatible {
+		for _, b := range riscvBoards {
+			if c == b.Compatible {
+				return b, true
+			}
+		}
+	}
+	if !riscv {
+		return riscvBoard{}, false
+	}
+	for _, b := range riscvBoards {
+		if strings.HasPrefix(strings.ToLower(model), strings.ToLower(b.Model)) {
+			return b, true
+		}
+	}
+	return riscvBoard{}, false
+}
+
+func isRISCV(logger *slog.Logger, cpuinfo string, compatible []string) bool {
+	_, err := readCPUInfo(logger, cpuinfo)
+	if err == nil {
+		return true
+	} else if err == ErrNotRISCV {
+		return false
+	}
+	for _, c := range compatible {
+		for _, s := range riscvSoCs {
+			if strings.HasPrefix(c, s) {
+				return true
+			}
+		}
+	}
+	return false
+}
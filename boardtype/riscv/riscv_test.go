@@ -0,0 +1,101 @@
+package riscv
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+)
+
+func TestGetRISCVBoard(t *testing.T) {
+	board, ok := getRISCVBoard([]string{"sipeed,lichee-rv-dock", "sipeed,lichee-rv", "allwinner,sun20i-d1"}, "", false)
+	require.True(t, ok)
+	assert.Equal(t, boardtype.SipeedLicheeRVDock, board.Type)
+	assert.True(t, board.Type.IsBoardType(boardtype.SipeedLicheeRV))
+
+	board, ok = getRISCVBoard([]string{"sophgo,sg2000"}, "Milk-V DuoS", true)
+	require.True(t, ok)
+	assert.Equal(t, boardtype.MilkVDuoS, board.Type)
+	board, ok = getRISCVBoard([]string{"sophgo,cv1800b"}, "Milk-V Duo", true)
+	require.True(t, ok)
+	assert.Equal(t, boardtype.MilkVDuo, board.Type)
+	board, ok = getRISCVBoard([]string{"starfive,jh7110"}, "StarFive VisionFive 2 v1.2A", true)
+	require.True(t, ok)
+	assert.Equal(t, boardtype.StarFiveVisionFive2, board.Type)
+
+	board, ok = getRISCVBoard([]string{"beagle,beaglev-ahead", "thead,th1520"}, "", false)
+	require.True(t, ok)
+	assert.True(t, board.Type.IsBoardType(boardtype.BeagleBoard))
+
+	_, ok = getRISCVBoard([]string{"spacemit,k1-x"}, "Milk-V Duo", false)
+	assert.False(t, ok)
+}
+
+func TestIsRISCV(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	dir := t.TempDir()
+	riscv := filepath.Join(dir, "riscv")
+	arm := filepath.Join(dir, "arm")
+	require.NoError(t, os.WriteFile(riscv, []byte("processor\t: 0\nhart\t\t: 0\nisa\t\t: rv64imafdcv\nmmu\t\t: sv39\n"), 0644))
+	require.NoError(t, os.WriteFile(arm, []byte("processor\t: 0\nmodel name\t: Cortex-A76\n"), 0644))
+	missing := filepath.Join(dir, "missing")
+
+	assert.True(t, isRISCV(logger, riscv, []string{"spacemit,k1-x"}))
+	assert.True(t, isRISCV(logger, riscv, nil))
+	assert.False(t, isRISCV(logger, arm, []string{"starfive,jh7110"}))
+	assert.True(t, isRISCV(logger, missing, []string{"sipeed,lichee-pi-4a", "thead,th1520"}))
+	assert.False(t, isRISCV(logger, missing, []string{"radxa,rock-5b", "rockchip,rk3588"}))
+}
+
+func TestParseISA(t *testing.T) {
+	isa := ParseISA("rv64imafdc_zicntr_zicsr_zifencei_zihpm_zba_zbb")
+	assert.Equal(t, "rv64", isa.Base)
+	assert.Equal(t, []string{"i", "m", "a", "f", "d", "c", "zicntr", "zicsr", "zifencei", "zihpm", "zba", "zbb"}, isa.Extensions)
+	assert.True(t, isa.HasExtension("Zba"))
+	assert.False(t, isa.HasExtension("v"))
+	assert.Equal(t, "rv64imafdc_zicntr_zicsr_zifencei_zihpm_zba_zbb", isa.String())
+
+	isa = ParseISA("rv64gcv_xtheadvector")
+	assert.Equal(t, []string{"i", "m", "a", "f", "d", "zicsr", "zifencei", "c", "v", "xtheadvector"}, isa.Extensions)
+}
+
+func TestReadCPUInfo(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	path := filepath.Join(t.TempDir(), "cpuinfo")
+	cpuinfo := `processor	: 0
+hart		: 1
+isa		: rv64imafdc_zicsr_zifencei_zba_zbb
+mmu		: sv39
+uarch		: sifive,u74-mc
+mvendorid	: 0x489
+marchid		: 0x8000000000000007
+mimpid		: 0x4210427
+
+processor	: 1
+hart		: 2
+isa		: rv64imafdc_zicsr_zifencei_zba
+mmu		: sv39
+uarch		: sifive,u74-mc
+mvendorid	: 0x489
+marchid		: 0x8000000000000007
+mimpid		: 0x4210427
+`
+	require.NoError(t, os.WriteFile(path, []byte(cpuinfo), 0644))
+	info, err := readCPUInfo(logger, path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, info.Harts)
+	assert.Equal(t, "sv39", info.MMU)
+	assert.Equal(t, "sifive,u74-mc", info.UArch)
+	assert.Equal(t, "0x489", info.MVendorID)
+	assert.True(t, info.ISA.HasExtension("zba"))
+	assert.False(t, info.ISA.HasExtension("zbb"))
+
+	require.NoError(t, os.WriteFile(path, []byte("processor\t: 0\nmodel name\t: Cortex-A76\n"), 0644))
+	_, err = readCPUInfo(logger, path)
+	assert.ErrorIs(t, err, ErrNotRISCV)
+}
@@ -0,0 +1,40 @@
+package boardtype
+
+var (
+	Radxa               = BoardType{Manufacturer: "Radxa", Model: "", SubModel: "", RAM: 0}
+	RadxaRock5B         = BoardType{Manufacturer: "Radxa", Model: "ROCK", SubModel: "5B", RAM: 0, BaseModel: &Radxa}
+	RadxaRock5B4GB      = BoardType{Manufacturer: "Radxa", Model: "ROCK", SubModel: "5B", RAM: 4096, BaseModel: &RadxaRock5B}
+	RadxaRock5B8GB      = BoardType{Manufacturer: "Radxa", Model: "ROCK", SubModel: "5B", RAM: 8192, BaseModel: &RadxaRock5B}
+	RadxaRock5B16GB     = BoardType{Manufacturer: "Radxa", Model: "ROCK", SubModel: "5B", RAM: 16384, BaseModel: &RadxaRock5B}
+	RadxaRock5B32GB     = BoardType{Manufacturer: "Radxa", Model: "ROCK", SubModel: "5B", RAM: 32768, BaseModel: &RadxaRock5B}
+	RadxaRock5A         = BoardType{Manufacturer: "Radxa", Model: "ROCK", SubModel: "5A", RAM: 0, BaseModel: &Radxa}
+	RadxaRock5A4GB      = BoardType{Manufacturer: "Radxa", Model: "ROCK", SubModel: "5A", RAM: 4096, BaseModel: &RadxaRock5A}
+	RadxaRock5A8GB      = BoardType{Manufacturer: "Radxa", Model: "ROCK", SubModel: "5A", RAM: 8192, BaseModel: &RadxaRock5A}
+	RadxaRock5A16GB     = BoardType{Manufacturer: "Radxa", Model: "ROCK", SubModel: "5A", RAM: 16384, BaseModel: &RadxaRock5A}
+	RadxaZero3          = BoardType{Manufacturer: "Radxa", Model: "ZERO", SubModel: "3", RAM: 0, BaseModel: &Radxa}
+	RadxaZero3W         = BoardType{Manufacturer: "Radxa", Model: "ZERO", SubModel: "3W", RAM: 0, BaseModel: &RadxaZero3}
+	RadxaZero3W1GB      = BoardType{Manufacturer: "Radxa", Model: "ZERO", SubModel: "3W", RAM: 1024, BaseModel: &RadxaZero3W}
+	RadxaZero3W2GB      = BoardType{Manufacturer: "Radxa", Model: "ZERO", SubModel: "3W", RAM: 2048, BaseModel: &RadxaZero3W}
+	RadxaZero3W4GB      = BoardType{Manufacturer: "Radxa", Model: "ZERO", SubModel: "3W", RAM: 4096, BaseModel: &RadxaZero3W}
+	RadxaZero3W8GB      = BoardType{Manufacturer: "Radxa", Model: "ZERO", SubModel: "3W", RAM: 8192, BaseModel: &RadxaZero3W}
+	RadxaZero3E         = BoardType{Manufacturer: "Radxa", Model: "ZERO", SubModel: "3E", RAM: 0, BaseModel: &RadxaZero3}
+	RadxaZero3E1GB      = BoardType{Manufacturer: "Radxa", Model: "ZERO", SubModel: "3E", RAM: 1024, BaseModel: &RadxaZero3E}
+	RadxaZero3E2GB      = BoardType{Manufacturer: "Radxa", Model: "ZERO", SubModel: "3E", RAM: 2048, BaseModel: &RadxaZero3E}
+	RadxaZero3E4GB      = BoardType{Manufacturer: "Radxa", Model: "ZERO", SubModel: "3E", RAM: 4096, BaseModel: &RadxaZero3E}
+	RadxaZero3E8GB      = BoardType{Manufacturer: "Radxa", Model: "ZERO", SubModel: "3E", RAM: 8192, BaseModel: &RadxaZero3E}
+	OrangePi            = BoardType{Manufacturer: "Orange Pi", Model: "", SubModel: "", RAM: 0}
+	OrangePi5           = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "5", RAM: 0, BaseModel: &OrangePi}
+	OrangePi54GB        = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "5", RAM: 4096, BaseModel: &OrangePi5}
+	OrangePi58GB        = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "5", RAM: 8192, BaseModel: &OrangePi5}
+	OrangePi516GB       = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "5", RAM: 16384, BaseModel: &OrangePi5}
+	OrangePi532GB       = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "5", RAM: 32768, BaseModel: &OrangePi5}
+	OrangePi5Plus       = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "5 Plus", RAM: 0, BaseModel: &OrangePi}
+	OrangePi5Plus4GB    = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "5 Plus", RAM: 4096, BaseModel: &OrangePi5Plus}
+	OrangePi5Plus8GB    = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "5 Plus", RAM: 8192, BaseModel: &OrangePi5Plus}
+	OrangePi5Plus16GB   = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "5 Plus", RAM: 16384, BaseModel: &OrangePi5Plus}
+	OrangePi5Plus32GB   = BoardType{Manufacturer: "Orange Pi", Model: "Orange Pi", SubModel: "5 Plus", RAM: 32768, BaseModel: &OrangePi5Plus}
+	Firefly             = BoardType{Manufacturer: "Firefly", Model: "", SubModel: "", RAM: 0}
+	FireflyROCRK3588SPC = BoardType{Manufacturer: "Firefly", Model: "ROC", SubModel: "RK3588S-PC", RAM: 0, BaseModel: &Firefly}
+	FireflyROCRK3568PC  = BoardType{Manufacturer: "Firefly", Model: "ROC", SubModel: "RK3568-PC", RAM: 0, BaseModel: &Firefly}
+	FireflyROCRK3566PC  = BoardType{Manufacturer: "Firefly", Model: "ROC", SubModel: "RK3566-PC", RAM: 0, BaseModel: &Firefly}
+)
@@ -0,0 +1,126 @@
+package rockchip
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+var (
+	ErrOTPNotFound   = errors.New("Rockchip OTP not found")
+	ErrUnknownSoC    = errors.New("unknown Rockchip SoC")
+	rockchipOTPPaths = []string{
+		"/sys/bus/nvmem/devices/rockchip-otp0/nvmem",
+		"/sys/bus/nvmem/devices/rockchip-efuse0/nvmem",
+	}
+)
+
+type rockchipOTPLayout struct {
+	Compatible          string
+	CPUCodeOffset       int
+	SpecificationOffset int
+	IDOffset            int
+}
+
+// Cell offsets from the otp and efuse nodes in the Rockchip device trees, the RK3399 efuse has
+// no cpu-code or specification cell.
+var rockchipOTPLayouts = []rockchipOTPLayout{
+	{"rockchip,rk3588s", 0x02, 0x06, 0x07},
+	{"rockchip,rk3588", 0x02, 0x06, 0x07},
+	{"rockchip,rk3568", 0x02, 0x07, 0x0a},
+	{"rockchip,rk3566", 0x02, 0x07, 0x0a},
+	{"rockchip,rk3399", -1, -1, 0x07},
+}
+
+const rockchipCPUIDSize = 16
+
+// SoC is the Rockchip SoC. The S variants (RK3588S) share the cpu code of the full part, the
+// letter is in the low 5 bits of the specification cell, 1 is A, so 0x13 is S. Model comes from
+// the compatible list when the OTP can't be read or the specification isn't programmed.
+type SoC struct {
+	Model         string
+	CPUCode       string
+	Specification string
+	CPUID         string
+}
+
+func (s SoC) String() string {
+	return s.Model
+}
+
+func GetSoC(logger *slog.Logger) (*SoC, error) {
+	compatible, err := identifier.GetDeviceTreeCompatible(logger)
+	if err != nil {
+		return nil, err
+	}
+	return readSoC(logger, compatible, rockchipOTPPaths)
+}
+
+func readSoC(logger *slog.Logger, compatible []string, paths []string) (*SoC, error) {
+	layout, ok := getOTPLayout(compatible)
+	if !ok {
+		logger.Debug("compatible does not list a known Rockchip SoC", slog.Any("compatible", compatible))
+		return nil, ErrUnknownSoC
+	}
+	soc := &SoC{Model: strings.ToUpper(strings.TrimPrefix(layout.Compatible, "rockchip,"))}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			logger.Debug("cannot read OTP", slog.String("path", path), slog.Any("error", err))
+			continue
+		}
+		soc.CPUCode, soc.Specification, soc.CPUID = parseOTP(data, layout)
+		break
+	}
+	soc.Model = getSoCModel(soc.Model, soc.CPUCode, soc.Specification)
+	if soc.CPUID == "" {
+		logger.Debug("CPU ID not available", slog.String("soc", soc.Model))
+	}
+	logger.Debug("Rockchip SoC", slog.String("model", soc.Model), slog.String("cpuCode", soc.CPUCode), slog.String("specification", soc.Specification), slog.String("cpuID", soc.CPUID))
+	return soc, nil
+}
+
+func getOTPLayout(compatible []string) (rockchipOTPLayout, bool) {
+	for _, l := range rockchipOTPLayouts {
+		for _, c := range compatible {
+			if c == l.Compatible {
+				return l, true
+			}
+		}
+	}
+	return rockchipOTPLayout{}, false
+}
+
+// getSoCModel prefers the OTP, the compatible model is kept when the cpu code isn't a known SoC,
+// or when the specification isn't programmed and the compatible already has the letter.
+func getSoCModel(compatibleModel string, cpuCode string, specification string) string {
+	if _, ok := getOTPLayout([]string{"rockchip,rk" + cpuCode}); cpuCode == "" || !ok {
+		return compatibleModel
+	}
+	model := "RK" + cpuCode
+	if specification == "" && strings.HasPrefix(compatibleModel, model) {
+		return compatibleModel
+	}
+	return model + specification
+}
+
+func parseOTP(data []byte, layout rockchipOTPLayout) (string, string, string) {
+	var cpuCode, specification, cpuID string
+	if layout.CPUCodeOffset >= 0 && len(data) >= layout.CPUCodeOffset+2 {
+		cpuCode = fmt.Sprintf("%02x%02x", data[layout.CPUCodeOffset], data[layout.CPUCodeOffset+1])
+	}
+	if layout.SpecificationOffset >= 0 && len(data) > layout.SpecificationOffset {
+		if spec := data[layout.SpecificationOffset] & 0x1f; spec > 0 && spec <= 26 {
+			specification = string(rune('A' + spec - 1))
+		}
+	}
+	if len(data) >= layout.IDOffset+rockchipCPUIDSize {
+		cpuID = hex.EncodeToString(data[layout.IDOffset : layout.IDOffset+rockchipCPUIDSize])
+	}
+	return cpuCode, specification, cpuID
+}
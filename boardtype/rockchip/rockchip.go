@@ -0,0 +1,111 @@
+package rockchip
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+func init() {
+	identifier.RegisterBoardIdentifier(NewRockchipIdentifier)
+}
+
+var (
+	ErrCannotIdentifyBoard = errors.New("cannot identify Rockchip board")
+)
+
+type rockchipBoard struct {
+	Compatible string
+	Model      string
+	Type       boardtype.SBC
+	Variants   []boardtype.SBC
+}
+
+var rockchipBoards = []rockchipBoard{
+	{"radxa,rock-5b", "ROCK 5B", boardtype.RadxaRock5B, []boardtype.SBC{boardtype.RadxaRock5B4GB, boardtype.RadxaRock5B8GB, boardtype.RadxaRock5B16GB, boardtype.RadxaRock5B32GB}},
+	{"radxa,rock-5a", "ROCK 5A", boardtype.RadxaRock5A, []boardtype.SBC{boardtype.RadxaRock5A4GB, boardtype.RadxaRock5A8GB, boardtype.RadxaRock5A16GB}},
+	{"radxa,zero-3w", "ZERO 3W", boardtype.RadxaZero3W, []boardtype.SBC{boardtype.RadxaZero3W1GB, boardtype.RadxaZero3W2GB, boardtype.RadxaZero3W4GB, boardtype.RadxaZero3W8GB}},
+	{"radxa,zero-3e", "ZERO 3E", boardtype.RadxaZero3E, []boardtype.SBC{boardtype.RadxaZero3E1GB, boardtype.RadxaZero3E2GB, boardtype.RadxaZero3E4GB, boardtype.RadxaZero3E8GB}},
+	{"xunlong,orangepi-5-plus", "Orange Pi 5 Plus", boardtype.OrangePi5Plus, []boardtype.SBC{boardtype.OrangePi5Plus4GB, boardtype.OrangePi5Plus8GB, boardtype.OrangePi5Plus16GB, boardtype.OrangePi5Plus32GB}},
+	{"xunlong,orangepi-5", "Orange Pi 5", boardtype.OrangePi5, []boardtype.SBC{boardtype.OrangePi54GB, boardtype.OrangePi58GB, boardtype.OrangePi516GB, boardtype.OrangePi532GB}},
+	{"firefly,roc-rk3588s-pc", "ROC-RK3588S-PC", boardtype.FireflyROCRK3588SPC, nil},
+	{"firefly,rk3568-roc-pc", "ROC-RK3568-PC", boardtype.FireflyROCRK3568PC, nil},
+	{"firefly,rk3566-roc-pc", "ROC-RK3566-PC", boardtype.FireflyROCRK3566PC, nil},
+}
+
+// RockchipBoardType is returned by GetBoardType when the SoC is known, the embedded SBC is the
+// board and SoC is read from the OTP, or the compatible list when the OTP can't be read.
+type RockchipBoardType struct {
+	boardtype.SBC
+	SoC *SoC
+}
+
+type rockchipIdentifier struct {
+	logger *slog.Logger
+}
+
+func NewRockchipIdentifier(logger *slog.Logger) identifier.BoardIdentifier {
+	logger.Debug("initializing Rockchip identifier")
+	newLogger := logger.With(slog.String("source", "Rockchip"))
+	return rockchipIdentifier{
+		logger: newLogger,
+	}
+}
+
+func (r rockchipIdentifier) Name() string {
+	return "Rockchip Identifier"
+}
+
+func (r rockchipIdentifier) GetBoardType() (boardtype.SBC, error) {
+	compatible, err := identifier.GetDeviceTreeCompatible(r.logger)
+	if err != nil {
+		return nil, ErrCannotIdentifyBoard
+	}
+	model, _ := identifier.GetDeviceTreeModel(r.logger)
+	board, ok := getRockchipBoard(compatible, model)
+	if !ok {
+		r.logger.Debug("unknown board", slog.String("model", model))
+		return nil, ErrCannotIdentifyBoard
+	}
+	variant, _ := identifier.ResolveRAMVariant(r.logger, board.Type, board.Variants)
+	soc, err := readSoC(r.logger, compatible, rockchipOTPPaths)
+	if err != nil {
+		return variant, nil
+	}
+	r.logger.Debug("board type", slog.String("type", variant.GetPrettyName()), slog.String("soc", soc.Model))
+	return RockchipBoardType{SBC: variant, SoC: soc}, nil
+}
+
+// BSP kernels don't always use the upstream board compatibles but the model still contains the
+// board name, so it is searched on Rockchip SoCs. Orange Pi 5 Plus is listed before Orange Pi 5
+// because the model search is a substring match.
+func getRockchipBoard(compatible []string, model string) (rockchipBoard, bool) {
+	for _, c := range compatible {
+		for _, b := range rockchipBoards {
+			if c == b.Compatible {
+				return b, true
+			}
+		}
+	}
+	if !isRockchip(compatible) {
+		return rockchipBoard{}, false
+	}
+	for _, b := range rockchipBoards {
+		if strings.Contains(model, b.Model) {
+			return b, true
+		}
+	}
+	return rockchipBoard{}, false
+}
+
+func isRockchip(compatible []string) bool {
+	for _, c := range compatible {
+		if strings.HasPrefix(c, "rockchip,") {
+			return true
+		}
+	}
+	return false
+}
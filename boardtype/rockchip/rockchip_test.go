@@ -0,0 +1,117 @@
+package rockchip
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/identifier"
+)
+
+func TestGetRockchipBoard(t *testing.T) {
+	tests := []struct {
+		compatible []string
+		model      string
+		expected   boardtype.SBC
+	}{
+		{[]string{"radxa,rock-5b", "rockchip,rk3588"}, "Radxa ROCK 5B", boardtype.RadxaRock5B},
+		{[]string{"xunlong,orangepi-5-plus", "rockchip,rk3588"}, "Xunlong Orange Pi 5 Plus", boardtype.OrangePi5Plus},
+		{[]string{"xunlong,orangepi-5", "rockchip,rk3588s"}, "Xunlong Orange Pi 5", boardtype.OrangePi5},
+		{[]string{"radxa,zero-3w", "rockchip,rk3566"}, "Radxa ZERO 3W", boardtype.RadxaZero3W},
+		{[]string{"rockchip,rk3588s-orangepi-5", "rockchip,rk3588"}, "Orange Pi 5", boardtype.OrangePi5},
+		{[]string{"firefly,rk3566-roc-pc", "rockchip,rk3566"}, "Firefly Station M2", boardtype.FireflyROCRK3566PC},
+	}
+	for _, test := range tests {
+		t.Run(test.model, func(t *testing.T) {
+			board, ok := getRockchipBoard(test.compatible, test.model)
+			require.True(t, ok)
+			assert.Equal(t, test.expected, board.Type)
+		})
+	}
+	_, ok := getRockchipBoard([]string{"raspberrypi,5-model-b", "brcm,bcm2712"}, "Orange Pi 5")
+	assert.False(t, ok)
+}
+
+func TestRockchipRAMVariants(t *testing.T) {
+	board, ok := getRockchipBoard([]string{"radxa,rock-5b", "rockchip,rk3588"}, "")
+	require.True(t, ok)
+	variant, ok := identifier.MatchRAMVariant(15700, board.Variants)
+	require.True(t, ok)
+	assert.Equal(t, boardtype.RadxaRock5B16GB, variant)
+	assert.True(t, variant.IsBoardType(boardtype.Radxa))
+}
+
+func TestReadSoC(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	otp := make([]byte, 0x100)
+	otp[0], otp[1] = 'R', 'K'
+	otp[2], otp[3] = 0x35, 0x88
+	otp[6] = 0x13
+	for i := 0; i < rockchipCPUIDSize; i++ {
+		otp[0x07+i] = byte(i + 1)
+	}
+	path := filepath.Join(t.TempDir(), "nvmem")
+	require.NoError(t, os.WriteFile(path, otp, 0644))
+
+	soc, err := readSoC(logger, []string{"xunlong,orangepi-5", "rockchip,rk3588s"}, []string{path})
+	require.NoError(t, err)
+	assert.Equal(t, "RK3588S", soc.Model)
+	assert.Equal(t, "3588", soc.CPUCode)
+	assert.Equal(t, "S", soc.Specification)
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", soc.CPUID)
+
+	soc, err = readSoC(logger, []string{"xunlong,orangepi-5", "rockchip,rk3588"}, []string{path})
+	require.NoError(t, err)
+	assert.Equal(t, "RK3588S", soc.Model)
+
+	otp[6] = 0
+	require.NoError(t, os.WriteFile(path, otp, 0644))
+	soc, err = readSoC(logger, []string{"xunlong,orangepi-5", "rockchip,rk3588s"}, []string{path})
+	require.NoError(t, err)
+	assert.Equal(t, "RK3588S", soc.Model)
+	assert.Empty(t, soc.Specification)
+	soc, err = readSoC(logger, []string{"radxa,rock-5b", "rockchip,rk3588"}, []string{path})
+	require.NoError(t, err)
+	assert.Equal(t, "RK3588", soc.Model)
+
+	soc, err = readSoC(logger, []string{"radxa,rock-5b", "rockchip,rk3588"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "RK3588", soc.Model)
+	assert.Empty(t, soc.CPUID)
+
+	_, err = readSoC(logger, []string{"brcm,bcm2712"}, nil)
+	assert.Equal(t, ErrUnknownSoC, err)
+}
+
+func TestGetSoCModel(t *testing.T) {
+	tests := []struct {
+		compatibleModel string
+		cpuCode         string
+		specification   string
+		expected        string
+	}{
+		{"RK3588", "3588", "S", "RK3588S"},
+		{"RK3588", "3588", "J", "RK3588J"},
+		{"RK3588S", "3588", "", "RK3588S"},
+		{"RK3568", "3566", "", "RK3566"},
+		{"RK3588", "0000", "", "RK3588"},
+		{"RK3399", "", "", "RK3399"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, getSoCModel(test.compatibleModel, test.cpuCode, test.specification))
+	}
+}
+
+func TestRockchipBoardType(t *testing.T) {
+	var board boardtype.SBC = RockchipBoardType{SBC: boardtype.OrangePi516GB, SoC: &SoC{Model: "RK3588S"}}
+	assert.True(t, board.IsBoardType(boardtype.OrangePi5))
+	assert.Equal(t, boardtype.OrangePi516GB.GetPrettyName(), board.GetPrettyName())
+	b, ok := board.(RockchipBoardType)
+	require.True(t, ok)
+	assert.Equal(t, "RK3588S", b.SoC.String())
+}
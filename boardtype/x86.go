@@ -0,0 +1,24 @@
+package boardtype
+
+var (
+	AAEON                = BoardType{Manufacturer: "AAEON", Model: "", SubModel: "", RAM: 0}
+	UP                   = BoardType{Manufacturer: "AAEON", Model: "UP", SubModel: "", RAM: 0, BaseModel: &AAEON}
+	UPBoard              = BoardType{Manufacturer: "AAEON", Model: "UP", SubModel: "Board", RAM: 0, BaseModel: &UP}
+	UPCore               = BoardType{Manufacturer: "AAEON", Model: "UP", SubModel: "Core", RAM: 0, BaseModel: &UP}
+	UPCorePlus           = BoardType{Manufacturer: "AAEON", Model: "UP", SubModel: "Core Plus", RAM: 0, BaseModel: &UPCore}
+	UPSquared            = BoardType{Manufacturer: "AAEON", Model: "UP", SubModel: "Squared", RAM: 0, BaseModel: &UP}
+	UPSquared6000        = BoardType{Manufacturer: "AAEON", Model: "UP", SubModel: "Squared 6000", RAM: 0, BaseModel: &UPSquared}
+	UPXtreme             = BoardType{Manufacturer: "AAEON", Model: "UP", SubModel: "Xtreme", RAM: 0, BaseModel: &UP}
+	UPXtremeI11          = BoardType{Manufacturer: "AAEON", Model: "UP", SubModel: "Xtreme i11", RAM: 0, BaseModel: &UPXtreme}
+	LattePanda           = BoardType{Manufacturer: "LattePanda", Model: "", SubModel: "", RAM: 0}
+	LattePandaAlpha      = BoardType{Manufacturer: "LattePanda", Model: "Alpha", SubModel: "", RAM: 0, BaseModel: &LattePanda}
+	LattePandaDelta      = BoardType{Manufacturer: "LattePanda", Model: "Delta", SubModel: "", RAM: 0, BaseModel: &LattePanda}
+	LattePandaSigma      = BoardType{Manufacturer: "LattePanda", Model: "Sigma", SubModel: "", RAM: 0, BaseModel: &LattePanda}
+	LattePandaMu         = BoardType{Manufacturer: "LattePanda", Model: "Mu", SubModel: "", RAM: 0, BaseModel: &LattePanda}
+	Seeed                = BoardType{Manufacturer: "Seeed", Model: "", SubModel: "", RAM: 0}
+	SeeedOdyssey         = BoardType{Manufacturer: "Seeed", Model: "Odyssey", SubModel: "", RAM: 0, BaseModel: &Seeed}
+	SeeedOdysseyX86J4105 = BoardType{Manufacturer: "Seeed", Model: "Odyssey", SubModel: "X86J4105", RAM: 0, BaseModel: &SeeedOdyssey}
+	SeeedOdysseyX86J4125 = BoardType{Manufacturer: "Seeed", Model: "Odyssey", SubModel: "X86J4125", RAM: 0, BaseModel: &SeeedOdyssey}
+	Intel                = BoardType{Manufacturer: "Intel", Model: "", SubModel: "", RAM: 0}
+	IntelNUC             = BoardType{Manufacturer: "Intel", Model: "NUC", SubModel: "", RAM: 0, BaseModel: &Intel}
+)
@@ -8,11 +8,14 @@ import (
 	"fmt"
 
 	"github.com/rinzlerlabs/sbcidentify"
+	"github.com/rinzlerlabs/sbcidentify/boardtype"
+	"github.com/rinzlerlabs/sbcidentify/boardtype/raspberrypi"
 )
 
 func main() {
 	debug := flag.Bool("d", false, "Enable debug logging")
 	output := flag.String("o", "StdOut", "Specify the log output, accept StdOut, StdErr, or a file path")
+	throttled := flag.Bool("t", false, "Print the throttling and under-voltage status on Raspberry Pi boards")
 	flag.Parse()
 
 	logLevel := new(slog.LevelVar)
@@ -52,6 +55,13 @@ func main() {
 		} else {
 			fmt.Printf("Error: %v\n", err)
 		}
+	} else if *throttled && board.IsBoardType(boardtype.RaspberryPi) {
+		status, err := raspberrypi.GetThrottledStatus(logger)
+		if err != nil {
+			fmt.Printf("%s (throttled status unavailable: %v)\n", board.GetPrettyName(), err)
+		} else {
+			fmt.Printf("%s (%s)\n", board.GetPrettyName(), status)
+		}
 	} else {
 		fmt.Println(board.GetPrettyName())
 	}
@@ -0,0 +1,56 @@
+package identifier
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	acpiTablesDir = "/sys/firmware/acpi/tables"
+)
+
+var (
+	ErrACPITableNotFound = errors.New("ACPI table not found")
+)
+
+// ACPITableHeader is the common header of an ACPI table, the OEM ID and table ID say whose
+// firmware built it.
+type ACPITableHeader struct {
+	Signature   string
+	OEMID       string
+	OEMTableID  string
+	OEMRevision uint32
+}
+
+// GetACPITableHeader reads the header of the ACPI table with the given signature, for example
+// DSDT, the tables are only readable by root.
+func GetACPITableHeader(logger *slog.Logger, signature string) (*ACPITableHeader, error) {
+	return readACPITableHeader(logger, filepath.Join(acpiTablesDir, signature))
+}
+
+func readACPITableHeader(logger *slog.Logger, path string) (*ACPITableHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		logger.Debug("cannot read ACPI table", slog.String("path", path), slog.Any("error", err))
+		return nil, ErrACPITableNotFound
+	}
+	defer f.Close()
+	c := make([]byte, 36)
+	if _, err := io.ReadFull(f, c); err != nil {
+		logger.Debug("ACPI table is too short", slog.String("path", path), slog.Any("error", err))
+		return nil, ErrACPITableNotFound
+	}
+	header := &ACPITableHeader{
+		Signature:   string(c[0:4]),
+		OEMID:       strings.TrimRight(string(c[10:16]), " \x00"),
+		OEMTableID:  strings.TrimRight(string(c[16:24]), " \x00"),
+		OEMRevision: binary.LittleEndian.Uint32(c[24:28]),
+	}
+	logger.Debug("ACPI table", slog.String("signature", header.Signature), slog.String("oemID", header.OEMID), slog.String("oemTableID", header.OEMTableID))
+	return header, nil
+}
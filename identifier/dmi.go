@@ -0,0 +1,118 @@
+package identifier
+
+import (
+	"encoding/binary"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	dmiDir        = "/sys/class/dmi/id"
+	dmiEntriesDir = "/sys/firmware/dmi/entries"
+)
+
+var (
+	ErrDMINotAvailable      = errors.New("DMI information not available")
+	ErrDMIMemoryNotReported = errors.New("DMI does not report any memory devices")
+)
+
+// DMIInfo is the SMBIOS system and baseboard information the kernel exports, x86 boards and
+// Arm boards booted through UEFI have it instead of a device tree.
+type DMIInfo struct {
+	SysVendor      string
+	ProductName    string
+	ProductVersion string
+	ProductFamily  string
+	BoardVendor    string
+	BoardName      string
+	BoardVersion   string
+	BIOSVendor     string
+	BIOSVersion    string
+}
+
+func GetDMIInfo(logger *slog.Logger) (*DMIInfo, error) {
+	return readDMIInfo(logger, dmiDir)
+}
+
+func readDMIInfo(logger *slog.Logger, dir string) (*DMIInfo, error) {
+	if _, err := os.Stat(dir); err != nil {
+		logger.Debug("DMI not available", slog.Any("error", err))
+		return nil, ErrDMINotAvailable
+	}
+	read := func(name string) string {
+		c, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(c))
+	}
+	info := &DMIInfo{
+		SysVendor:      read("sys_vendor"),
+		ProductName:    read("product_name"),
+		ProductVersion: read("product_version"),
+		ProductFamily:  read("product_family"),
+		BoardVendor:    read("board_vendor"),
+		BoardName:      read("board_name"),
+		BoardVersion:   read("board_version"),
+		BIOSVendor:     read("bios_vendor"),
+		BIOSVersion:    read("bios_version"),
+	}
+	if info.SysVendor == "" && info.ProductName == "" && info.BoardVendor == "" && info.BoardName == "" {
+		logger.Debug("DMI information is empty")
+		return nil, ErrDMINotAvailable
+	}
+	logger.Debug("DMI", slog.String("sysVendor", info.SysVendor), slog.String("productName", info.ProductName), slog.String("boardVendor", info.BoardVendor), slog.String("boardName", info.BoardName))
+	return info, nil
+}
+
+// GetDMIMemory adds up the memory devices (SMBIOS type 17) in MB, unlike MemTotal this is the
+// installed RAM.
+func GetDMIMemory(logger *slog.Logger) (int, error) {
+	return readDMIMemory(logger, dmiEntriesDir)
+}
+
+func readDMIMemory(logger *slog.Logger, dir string) (int, error) {
+	entries, err := filepath.Glob(filepath.Join(dir, "17-*", "raw"))
+	if err != nil || len(entries) == 0 {
+		logger.Debug("no DMI memory devices", slog.String("path", dir))
+		return 0, ErrDMIMemoryNotReported
+	}
+	total := 0
+	for _, e := range entries {
+		c, err := os.ReadFile(e)
+		if err != nil {
+			logger.Debug("cannot read DMI memory device", slog.String("path", e), slog.Any("error", err))
+			continue
+		}
+		total += parseDMIMemoryDevice(c)
+	}
+	if total == 0 {
+		return 0, ErrDMIMemoryNotReported
+	}
+	logger.Debug("DMI memory", slog.Int("MB", total))
+	return total, nil
+}
+
+// parseDMIMemoryDevice returns the size of a type 17 structure in MB, 0x7fff means the size is
+// in the extended size field and bit 15 set means the size is in kB.
+func parseDMIMemoryDevice(c []byte) int {
+	if len(c) < 0x0e || c[0] != 17 || int(c[1]) < 0x0e {
+		return 0
+	}
+	size := binary.LittleEndian.Uint16(c[0x0c:])
+	switch {
+	case size == 0 || size == 0xffff:
+		return 0
+	case size == 0x7fff:
+		if len(c) < 0x20 || int(c[1]) < 0x20 {
+			return 0
+		}
+		return int(binary.LittleEndian.Uint32(c[0x1c:]) & 0x7fffffff)
+	case size&0x8000 != 0:
+		return int(size&0x7fff) / 1024
+	}
+	return int(size)
+}
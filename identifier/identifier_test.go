@@ -0,0 +1,154 @@
+package identifier
+
+import (
+	"encoding/binary"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	boardType "github.com/rinzlerlabs/sbcidentify/boardtype"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestReadSoC(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	soc0 := writeFiles(t, map[string]string{"family": "Tegra\n", "machine": "NVIDIA Jetson AGX Orin Developer Kit\n", "revision": "1\n", "soc_id": "35\n"})
+	fuses := writeFiles(t, map[string]string{"tegra_chip_id": "35\n", "sku": "0xd0\n"})
+	soc, err := readSoC(logger, soc0, []string{fuses}, nil, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "Tegra234", soc.Name)
+	assert.Equal(t, 0x23, soc.ChipID)
+	assert.Equal(t, "A01", soc.Revision)
+	assert.Equal(t, "0xd0", soc.SKU)
+	assert.Equal(t, "Tegra234 rev A01", soc.String())
+
+	soc0 = writeFiles(t, map[string]string{"family": "Freescale i.MX\n", "machine": "Toradex Verdin iMX8M Plus\n", "revision": "1.1\n", "soc_id": "i.MX8MP\n", "serial_number": "0B2E1B1C8A2F3D91\n"})
+	soc, err = readSoC(logger, soc0, nil, nil, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "0B2E1B1C8A2F3D91", soc.SerialNumber)
+	assert.Equal(t, "i.MX8MP rev 1.1", soc.String())
+
+	pi5 := []string{"raspberrypi,5-model-b", "brcm,bcm2712"}
+	soc, err = readSoC(logger, t.TempDir(), nil, pi5, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "BCM2712", soc.String())
+
+	dt := writeFiles(t, map[string]string{"soc@107c000000/pinctrl@7d504100/compatible": "brcm,bcm2712d0-pinctrl\x00", "soc@107c000000/pinctrl@7d510700/compatible": "brcm,bcm2712d0-aon-pinctrl\x00"})
+	soc, err = readSoC(logger, t.TempDir(), nil, pi5, dt)
+	require.NoError(t, err)
+	assert.Equal(t, "BCM2712 rev D0", soc.String())
+
+	dt = writeFiles(t, map[string]string{"soc@107c000000/pinctrl@7d504100/compatible": "brcm,bcm2712c0-pinctrl\x00"})
+	soc, err = readSoC(logger, t.TempDir(), nil, pi5, dt)
+	require.NoError(t, err)
+	assert.Equal(t, "C1", soc.Revision)
+
+	_, err = readSoC(logger, t.TempDir(), nil, nil, t.TempDir())
+	assert.Equal(t, ErrSoCNotFound, err)
+}
+
+func TestReadDMIInfo(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	dir := writeFiles(t, map[string]string{"sys_vendor": "HARDKERNEL\n", "product_name": "ODROID-H3\n", "board_vendor": "HARDKERNEL\n", "board_name": "ODROID-H3\n", "product_version": "1.0\n"})
+	info, err := readDMIInfo(logger, dir)
+	require.NoError(t, err)
+	assert.Equal(t, "HARDKERNEL", info.SysVendor)
+	assert.Equal(t, "ODROID-H3", info.ProductName)
+	assert.Equal(t, "1.0", info.ProductVersion)
+	assert.Empty(t, info.BIOSVendor)
+
+	_, err = readDMIInfo(logger, t.TempDir())
+	assert.Equal(t, ErrDMINotAvailable, err)
+	_, err = readDMIInfo(logger, filepath.Join(t.TempDir(), "missing"))
+	assert.Equal(t, ErrDMINotAvailable, err)
+}
+
+func TestReadDMIMemory(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	device := func(size uint16, extended uint32) string {
+		c := make([]byte, 0x28)
+		c[0], c[1] = 17, 0x28
+		binary.LittleEndian.PutUint16(c[0x0c:], size)
+		binary.LittleEndian.PutUint32(c[0x1c:], extended)
+		return string(c)
+	}
+	dir := writeFiles(t, map[string]string{"17-0/raw": device(4096, 0), "17-1/raw": device(0x7fff, 36864), "17-2/raw": device(0, 0), "16-0/raw": device(8192, 0)})
+	memMB, err := readDMIMemory(logger, dir)
+	require.NoError(t, err)
+	assert.Equal(t, 40960, memMB)
+
+	dir = writeFiles(t, map[string]string{"17-0/raw": device(0x8000|16384, 0)})
+	memMB, err = readDMIMemory(logger, dir)
+	require.NoError(t, err)
+	assert.Equal(t, 16, memMB)
+
+	_, err = readDMIMemory(logger, t.TempDir())
+	assert.Equal(t, ErrDMIMemoryNotReported, err)
+}
+
+func TestReadACPITableHeader(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c := make([]byte, 64)
+	copy(c, "DSDT")
+	copy(c[10:], "RPIFDN")
+	copy(c[16:], "RPI4    ")
+	binary.LittleEndian.PutUint32(c[24:], 2)
+	dir := writeFiles(t, map[string]string{"DSDT": string(c), "SSDT": "SSDT"})
+	header, err := readACPITableHeader(logger, filepath.Join(dir, "DSDT"))
+	require.NoError(t, err)
+	assert.Equal(t, "DSDT", header.Signature)
+	assert.Equal(t, "RPIFDN", header.OEMID)
+	assert.Equal(t, "RPI4", header.OEMTableID)
+	assert.Equal(t, uint32(2), header.OEMRevision)
+
+	_, err = readACPITableHeader(logger, filepath.Join(dir, "SSDT"))
+	assert.Equal(t, ErrACPITableNotFound, err)
+	_, err = readACPITableHeader(logger, filepath.Join(dir, "FACP"))
+	assert.Equal(t, ErrACPITableNotFound, err)
+}
+func TestResolveRAMVariant(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	variants := []boardType.SBC{boardType.RadxaRock5B4GB, boardType.RadxaRock5B8GB, boardType.RadxaRock5B16GB, boardType.RadxaRock5B32GB}
+	meminfo := func(kB string) string {
+		return "MemTotal:       " + kB + " kB\nMemFree:         1048576 kB\n"
+	}
+	tests := []struct {
+		name     string
+		meminfo  string
+		variants []boardType.SBC
+		expected boardType.SBC
+		resolved bool
+	}{
+		{"8GB", meminfo("7916532"), variants, boardType.RadxaRock5B8GB, true},
+		{"16GB", meminfo("16111040"), variants, boardType.RadxaRock5B16GB, true},
+		{"no match", meminfo("1015024"), variants, boardType.RadxaRock5B, false},
+		{"no variants", meminfo("7916532"), nil, boardType.RadxaRock5B, false},
+		{"no MemTotal", "MemFree:         1048576 kB\n", variants, boardType.RadxaRock5B, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := writeFiles(t, map[string]string{"meminfo": test.meminfo})
+			board, resolved := resolveRAMVariant(logger, filepath.Join(dir, "meminfo"), boardType.RadxaRock5B, test.variants)
+			assert.Equal(t, test.expected, board)
+			assert.Equal(t, test.resolved, resolved)
+		})
+	}
+	board, resolved := resolveRAMVariant(logger, filepath.Join(t.TempDir(), "meminfo"), boardType.RadxaRock5B, variants)
+	assert.Equal(t, boardType.RadxaRock5B, board)
+	assert.False(t, resolved)
+}
@@ -0,0 +1,177 @@
+package identifier
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	soc0Dir           = "/sys/devices/soc0"
+	procDeviceTreeDir = "/proc/device-tree"
+)
+
+var (
+	ErrSoCNotFound = errors.New("cannot identify SoC")
+	tegraFuseDirs  = []string{"/sys/module/tegra_fuse/parameters", "/sys/devices/platform/tegra-fuse"}
+	tegraChipIDs   = map[int]string{0x21: "Tegra210", 0x18: "Tegra186", 0x19: "Tegra194", 0x23: "Tegra234", 0x26: "Tegra264"}
+	tegraRevisions = []string{"", "A01", "A02", "A03", "A03 prime", "A04"}
+	// The firmware loads a separate device tree for the BCM2712 D0, which only differs in the pin
+	// controllers. C0 was never sold, so the C0 pin controllers mean a C1.
+	bcm2712Steppings = map[string]string{
+		"brcm,bcm2712c0-pinctrl":     "C1",
+		"brcm,bcm2712c0-aon-pinctrl": "C1",
+		"brcm,bcm2712d0-pinctrl":     "D0",
+		"brcm,bcm2712d0-aon-pinctrl": "D0",
+	}
+)
+
+// SoC is what the kernel reports in /sys/devices/soc0, Name is derived from it and falls back to
+// the SoC in the device tree compatible list when soc0 doesn't exist.
+type SoC struct {
+	Family       string
+	Machine      string
+	Revision     string
+	ID           string
+	SerialNumber string
+	Name         string
+	ChipID       int
+	SKU          string
+	Fuses        map[string]string
+}
+
+func (s SoC) IsTegra() bool {
+	return s.Family == "Tegra"
+}
+
+func (s SoC) String() string {
+	if s.Revision == "" {
+		return s.Name
+	}
+	return s.Name + " rev " + s.Revision
+}
+
+func GetSoC(logger *slog.Logger) (*SoC, error) {
+	compatible, _ := GetDeviceTreeCompatible(logger)
+	return readSoC(logger, soc0Dir, tegraFuseDirs, compatible, procDeviceTreeDir)
+}
+
+func readSoC(logger *slog.Logger, dir string, fuseDirs []string, compatible []string, deviceTreeDir string) (*SoC, error) {
+	read := func(name string) string {
+		c, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(c))
+	}
+	soc := &SoC{
+		Family:       read("family"),
+		Machine:      read("machine"),
+		Revision:     read("revision"),
+		ID:           read("soc_id"),
+		SerialNumber: read("serial_number"),
+		Fuses:        make(map[string]string),
+	}
+	if soc.IsTegra() {
+		readTegraFuses(logger, soc, fuseDirs)
+	}
+	switch {
+	case soc.IsTegra():
+		chipID, err := strconv.Atoi(soc.ID)
+		if err == nil {
+			soc.ChipID = chipID
+		}
+		if name, ok := tegraChipIDs[soc.ChipID]; ok {
+			soc.Name = name
+		} else {
+			soc.Name = fmt.Sprintf("Tegra 0x%02x", soc.ChipID)
+		}
+		if rev, err := strconv.Atoi(soc.Revision); err == nil && rev > 0 && rev < len(tegraRevisions) {
+			soc.Revision = tegraRevisions[rev]
+		}
+	case soc.ID != "" && !isNumeric(soc.ID):
+		soc.Name = soc.ID
+	case soc.Machine != "":
+		soc.Name = soc.Machine
+	default:
+		soc.Name = getSoCFromCompatible(compatible)
+		if soc.Name == "BCM2712" {
+			soc.Revision = getBCM2712Stepping(logger, deviceTreeDir)
+		}
+	}
+	if soc.Name == "" {
+		logger.Debug("cannot identify SoC", slog.String("dir", dir))
+		return nil, ErrSoCNotFound
+	}
+	logger.Debug("SoC", slog.String("name", soc.Name), slog.String("family", soc.Family), slog.String("revision", soc.Revision), slog.String("sku", soc.SKU))
+	return soc, nil
+}
+
+// readTegraFuses reads the values the tegra-fuse driver exports, the names differ between the
+// upstream and L4T kernels so every file is kept.
+func readTegraFuses(logger *slog.Logger, soc *SoC, fuseDirs []string) {
+	for _, dir := range fuseDirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			logger.Debug("cannot read tegra fuses", slog.String("dir", dir), slog.Any("error", err))
+			continue
+		}
+		for _, e := range entries {
+			if !e.Type().IsRegular() {
+				continue
+			}
+			c, err := os.ReadFile(filepath.Join(dir, e.Name()))
+			if err != nil {
+				continue
+			}
+			soc.Fuses[e.Name()] = strings.TrimSpace(string(c))
+		}
+	}
+	for _, key := range []string{"sku", "sku_id", "tegra_sku_id"} {
+		if v, ok := soc.Fuses[key]; ok && soc.SKU == "" {
+			soc.SKU = v
+		}
+	}
+	if soc.ID == "" {
+		soc.ID = soc.Fuses["tegra_chip_id"]
+	}
+}
+
+// getSoCFromCompatible takes the last compatible entry, which is the SoC, so brcm,bcm2712
+// becomes BCM2712.
+func getSoCFromCompatible(compatible []string) string {
+	if len(compatible) == 0 {
+		return ""
+	}
+	_, name, ok := strings.Cut(compatible[len(compatible)-1], ",")
+	if !ok {
+		return ""
+	}
+	return strings.ToUpper(name)
+}
+
+func getBCM2712Stepping(logger *slog.Logger, deviceTreeDir string) string {
+	paths, _ := filepath.Glob(filepath.Join(deviceTreeDir, "soc*", "pinctrl@*", "compatible"))
+	for _, path := range paths {
+		c, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, compatible := range strings.Split(string(c), "\x00") {
+			if stepping, ok := bcm2712Steppings[compatible]; ok {
+				return stepping
+			}
+		}
+	}
+	logger.Debug("cannot read BCM2712 stepping", slog.String("dir", deviceTreeDir))
+	return ""
+}
+
+func isNumeric(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
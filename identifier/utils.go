@@ -1,21 +1,30 @@
 package identifier
 
 import (
+	"bufio"
+	"encoding/binary"
 	"errors"
 	"log/slog"
 	"os"
 	"strconv"
 	"strings"
+
+	boardType "github.com/rinzlerlabs/sbcidentify/boardtype"
 )
 
 const (
-	procDeviceTreeModelFile     = "/proc/device-tree/model"
-	firmwareDeviceTreeModelFile = "/sys/firmware/devicetree/base/model"
-	socIdFile                   = "/sys/devices/soc0/soc_id"
+	procDeviceTreeModelFile          = "/proc/device-tree/model"
+	firmwareDeviceTreeModelFile      = "/sys/firmware/devicetree/base/model"
+	procDeviceTreeCompatibleFile     = "/proc/device-tree/compatible"
+	firmwareDeviceTreeCompatibleFile = "/sys/firmware/devicetree/base/compatible"
+	socIdFile                        = "/sys/devices/soc0/soc_id"
+	procMeminfoFile                  = "/proc/meminfo"
 )
 
 var (
-	ErrCannotIdentifyBoard = errors.New("cannot identify board")
+	ErrCannotIdentifyBoard       = errors.New("cannot identify board")
+	ErrInvalidDeviceTreeProperty = errors.New("invalid device tree property")
+	ErrMemTotalNotFound          = errors.New("MemTotal not found in meminfo")
 )
 
 func GetDeviceTreeBaseModel(logger *slog.Logger) (string, error) {
@@ -48,11 +57,125 @@ func GetDeviceTreeModel(logger *slog.Logger) (string, error) {
 	return str, nil
 }
 
+func GetDeviceTreeCompatible(logger *slog.Logger) ([]string, error) {
+	for _, f := range []string{procDeviceTreeCompatibleFile, firmwareDeviceTreeCompatibleFile} {
+		c, err := os.ReadFile(f)
+		if err != nil {
+			logger.Debug("cannot read device tree compatible file", slog.String("path", f), slog.Any("error", err))
+			continue
+		}
+		compatible := ParseDeviceTreeStringList(c)
+		logger.Debug("device tree compatible", slog.Any("compatible", compatible))
+		return compatible, nil
+	}
+	return nil, ErrCannotIdentifyBoard
+}
+
+func ParseDeviceTreeStringList(c []byte) []string {
+	ret := make([]string, 0)
+	for _, s := range strings.Split(string(c), "\x00") {
+		if s = strings.TrimSpace(s); s != "" {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
 func GetSoCId() (int, error) {
-	c, err := os.ReadFile("/sys/devices/soc0/soc_id")
+	c, err := os.ReadFile(socIdFile)
 	if err != nil {
 		return 0, err
 	}
-	str := string(c)
+	str := strings.TrimSpace(string(c))
 	return strconv.Atoi(str)
 }
+
+func ReadDeviceTreeString(logger *slog.Logger, path string) (string, error) {
+	c, err := os.ReadFile(path)
+	if err != nil {
+		logger.Debug("cannot read device tree property", slog.String("path", path), slog.Any("error", err))
+		return "", err
+	}
+	return strings.TrimRight(string(c), "\x00\n "), nil
+}
+
+func ReadDeviceTreeUint32(logger *slog.Logger, path string) (uint32, error) {
+	c, err := os.ReadFile(path)
+	if err != nil {
+		logger.Debug("cannot read device tree property", slog.String("path", path), slog.Any("error", err))
+		return 0, err
+	}
+	if len(c) != 4 {
+		logger.Debug("device tree property is not a u32", slog.String("path", path), slog.Int("length", len(c)))
+		return 0, ErrInvalidDeviceTreeProperty
+	}
+	return binary.BigEndian.Uint32(c), nil
+}
+
+// GetInstalledMemory returns MemTotal in MB, this is less than the installed RAM because the
+// kernel and any firmware carveouts are excluded.
+func GetInstalledMemory(logger *slog.Logger) (int, error) {
+	return readInstalledMemory(logger, procMeminfoFile)
+}
+
+func readInstalledMemory(logger *slog.Logger, path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		logger.Debug("cannot read meminfo", slog.Any("error", err))
+		return 0, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "MemTotal:" {
+			continue
+		}
+		kb, err := strconv.Atoi(fields[1])
+		if err != nil {
+			logger.Debug("cannot parse MemTotal", slog.String("line", scanner.Text()))
+			return 0, ErrMemTotalNotFound
+		}
+		logger.Debug("installed memory", slog.Int("kB", kb))
+		return kb / 1024, nil
+	}
+	return 0, ErrMemTotalNotFound
+}
+
+// MatchRAMVariant picks the smallest variant with at least memMB of RAM, variants whose RAM is
+// more than double memMB are not considered a match.
+func MatchRAMVariant(memMB int, variants []boardType.SBC) (boardType.SBC, bool) {
+	var best boardType.SBC
+	for _, v := range variants {
+		if v.GetRAM() < memMB || v.GetRAM() > memMB*2 {
+			continue
+		}
+		if best == nil || v.GetRAM() < best.GetRAM() {
+			best = v
+		}
+	}
+	return best, best != nil
+}
+
+// ResolveRAMVariant returns the variant of base that matches MemTotal, the bool is false and base
+// is returned when there are no variants, meminfo can't be read or none of them match.
+func ResolveRAMVariant(logger *slog.Logger, base boardType.SBC, variants []boardType.SBC) (boardType.SBC, bool) {
+	return resolveRAMVariant(logger, procMeminfoFile, base, variants)
+}
+
+func resolveRAMVariant(logger *slog.Logger, path string, base boardType.SBC, variants []boardType.SBC) (boardType.SBC, bool) {
+	if len(variants) == 0 {
+		return base, false
+	}
+	memMB, err := readInstalledMemory(logger, path)
+	if err != nil {
+		return base, false
+	}
+	variant, ok := MatchRAMVariant(memMB, variants)
+	if !ok {
+		logger.Debug("installed memory does not match any variant", slog.String("type", base.GetPrettyName()), slog.Int("memMB", memMB))
+		return base, false
+	}
+	logger.Debug("resolved RAM variant from meminfo", slog.String("type", variant.GetPrettyName()), slog.Int("memMB", memMB))
+	return variant, true
+}
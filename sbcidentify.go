@@ -8,8 +8,17 @@ import (
 	"github.com/rinzlerlabs/sbcidentify/boardtype"
 	"github.com/rinzlerlabs/sbcidentify/identifier"
 
+	_ "github.com/rinzlerlabs/sbcidentify/boardtype/allwinner"
+	_ "github.com/rinzlerlabs/sbcidentify/boardtype/amlogic"
+	_ "github.com/rinzlerlabs/sbcidentify/boardtype/beagle"
+	_ "github.com/rinzlerlabs/sbcidentify/boardtype/dmi"
+	_ "github.com/rinzlerlabs/sbcidentify/boardtype/hardkernel"
 	_ "github.com/rinzlerlabs/sbcidentify/boardtype/nvidia"
+	_ "github.com/rinzlerlabs/sbcidentify/boardtype/nxp"
+	_ "github.com/rinzlerlabs/sbcidentify/boardtype/qualcomm"
 	_ "github.com/rinzlerlabs/sbcidentify/boardtype/raspberrypi"
+	_ "github.com/rinzlerlabs/sbcidentify/boardtype/riscv"
+	_ "github.com/rinzlerlabs/sbcidentify/boardtype/rockchip"
 )
 
 var (
@@ -43,6 +52,25 @@ func GetBoardType() (boardtype.SBC, error) {
 	return nil, final
 }
 
+type Board struct {
+	Type boardtype.SBC
+	SoC  *identifier.SoC
+}
+
+// GetBoard identifies the board and the SoC it is built on, SoC is nil when the kernel doesn't
+// report one.
+func GetBoard() (*Board, error) {
+	boardType, err := GetBoardType()
+	if err != nil {
+		return nil, err
+	}
+	soc, err := identifier.GetSoC(logger)
+	if err != nil {
+		logger.Debug("cannot identify SoC", slog.Any("error", err))
+	}
+	return &Board{Type: boardType, SoC: soc}, nil
+}
+
 func IsBoardType(boardType boardtype.SBC) bool {
 	board, err := GetBoardType()
 	if err != nil {